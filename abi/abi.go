@@ -0,0 +1,328 @@
+package abi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// ABI is a parsed solidity contract abi. Methods are keyed by their signature so overloaded functions
+// are kept apart, use Method to look one up by name.
+type ABI struct {
+	Constructor *Method
+	Methods     map[string]Method
+	Events      map[string]Event
+}
+
+// Argument is an input or output of a method, or an event parameter.
+type Argument struct {
+	Name    string
+	Type    Type
+	Indexed bool
+}
+
+type Arguments []Argument
+
+// Method is a contract function or the constructor.
+type Method struct {
+	Name            string
+	Inputs          Arguments
+	Outputs         Arguments
+	Constant        bool
+	StateMutability string
+}
+
+type Event struct {
+	Name      string
+	Inputs    Arguments
+	Anonymous bool
+}
+
+type jsonArgument struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Indexed bool   `json:"indexed,omitempty"`
+}
+
+type jsonField struct {
+	Type            string         `json:"type"`
+	Name            string         `json:"name"`
+	Inputs          []jsonArgument `json:"inputs"`
+	Outputs         []jsonArgument `json:"outputs"`
+	Constant        bool           `json:"constant"`
+	Anonymous       bool           `json:"anonymous"`
+	StateMutability string         `json:"stateMutability"`
+}
+
+// JSON parses the abi json emitted by the solidity compiler.
+func JSON(reader io.Reader) (ABI, error) {
+	fields := make([]jsonField, 0)
+	if err := json.NewDecoder(reader).Decode(&fields); err != nil {
+		return ABI{}, fmt.Errorf("abi: failed to decode abi json,err:%w", err)
+	}
+	abi := ABI{Methods: make(map[string]Method), Events: make(map[string]Event)}
+	for _, field := range fields {
+		inputs, err := newArguments(field.Inputs)
+		if err != nil {
+			return ABI{}, err
+		}
+		outputs, err := newArguments(field.Outputs)
+		if err != nil {
+			return ABI{}, err
+		}
+		switch field.Type {
+		case "constructor":
+			abi.Constructor = &Method{Inputs: inputs, StateMutability: field.StateMutability}
+		case "function", "":
+			method := Method{
+				Name:            field.Name,
+				Inputs:          inputs,
+				Outputs:         outputs,
+				Constant:        field.Constant || field.StateMutability == "view" || field.StateMutability == "pure",
+				StateMutability: field.StateMutability,
+			}
+			abi.Methods[method.Sig()] = method
+		case "event":
+			abi.Events[field.Name] = Event{Name: field.Name, Inputs: inputs, Anonymous: field.Anonymous}
+		}
+	}
+	return abi, nil
+}
+
+func newArguments(fields []jsonArgument) (Arguments, error) {
+	arguments := make(Arguments, len(fields))
+	for i, field := range fields {
+		t, err := NewType(field.Type)
+		if err != nil {
+			return nil, err
+		}
+		arguments[i] = Argument{Name: field.Name, Type: t, Indexed: field.Indexed}
+	}
+	return arguments, nil
+}
+
+// Method returns the method with the given signature, e.g. SayHello(bytes,string), or with the given
+// name when it is not overloaded.
+func (abi ABI) Method(nameOrSig string) (Method, error) {
+	nameOrSig = strings.Replace(nameOrSig, " ", "", -1)
+	if method, ok := abi.Methods[nameOrSig]; ok {
+		return method, nil
+	}
+	var found []Method
+	for _, method := range abi.Methods {
+		if method.Name == nameOrSig {
+			found = append(found, method)
+		}
+	}
+	if len(found) == 0 && strings.Contains(nameOrSig, "(") {
+		// the signature may spell int and uint without size
+		for _, method := range abi.Methods {
+			if method.sig(func(t Type) string { return string(t.SolidityVarType()) }) == nameOrSig {
+				found = append(found, method)
+			}
+		}
+	}
+	switch len(found) {
+	case 0:
+		return Method{}, fmt.Errorf("abi: method %v not found", nameOrSig)
+	case 1:
+		return found[0], nil
+	}
+	return Method{}, fmt.Errorf("abi: method %v is overloaded, use its signature", nameOrSig)
+}
+
+// Sig returns the signature of the method, e.g. SayHello(bytes,string).
+func (method Method) Sig() string {
+	return method.sig(Type.canonical)
+}
+
+func (method Method) sig(typeName func(Type) string) string {
+	types := make([]string, len(method.Inputs))
+	for i, input := range method.Inputs {
+		types[i] = typeName(input.Type)
+	}
+	return fmt.Sprintf("%v(%v)", method.Name, strings.Join(types, ","))
+}
+
+// OutTypes returns the outTypes of the method in the form CallRestBizParam expects, e.g. ["bytes","string"].
+func (method Method) OutTypes() string {
+	types := make([]string, len(method.Outputs))
+	for i, output := range method.Outputs {
+		types[i] = string(output.Type.SolidityVarType())
+	}
+	bytes, _ := json.Marshal(types)
+	return string(bytes)
+}
+
+func (arguments Arguments) types() []Type {
+	types := make([]Type, len(arguments))
+	for i, argument := range arguments {
+		types[i] = argument.Type
+	}
+	return types
+}
+
+func (arguments Arguments) normalize(args []interface{}) ([]interface{}, error) {
+	if len(args) != len(arguments) {
+		return nil, fmt.Errorf("abi: need %v arguments,actual:%v", len(arguments), len(args))
+	}
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		value, err := arguments[i].Type.normalize(arg)
+		if err != nil {
+			return nil, fmt.Errorf("abi: argument %v: %w", i, err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// Pack encodes args as an abi tuple of the arguments.
+func (arguments Arguments) Pack(args ...interface{}) ([]byte, error) {
+	values, err := arguments.normalize(args)
+	if err != nil {
+		return nil, err
+	}
+	return packTuple(arguments.types(), values)
+}
+
+// UnpackValues decodes an abi tuple of the arguments, see Type for the go types of the values.
+func (arguments Arguments) UnpackValues(data []byte) ([]interface{}, error) {
+	return unpackTuple(arguments.types(), data)
+}
+
+// Pack encodes args as the abi input of the method, without function selector.
+func (abi ABI) Pack(nameOrSig string, args ...interface{}) ([]byte, error) {
+	method, err := abi.Method(nameOrSig)
+	if err != nil {
+		return nil, err
+	}
+	return method.Inputs.Pack(args...)
+}
+
+// EncodeInputParamList encodes args as the inputParamListStr of CallRestBizParam: a json array with
+// integers as numbers, bytes base64 encoded and identities hex encoded.
+func (abi ABI) EncodeInputParamList(nameOrSig string, args ...interface{}) (string, error) {
+	method, err := abi.Method(nameOrSig)
+	if err != nil {
+		return "", err
+	}
+	values, err := method.Inputs.normalize(args)
+	if err != nil {
+		return "", err
+	}
+	params := make([]interface{}, len(values))
+	for i, value := range values {
+		params[i] = method.Inputs[i].Type.jsonValue(value)
+	}
+	bytes, err := json.Marshal(params)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// Unpack decodes the output of the method into v, which is one of:
+//   - a pointer to a struct, fields are matched by `abi:"name"` tag or by name when the outputs are
+//     named and by position otherwise
+//   - a pointer to a []interface{}, pointer elements are filled in place, other elements are replaced
+//   - a pointer to a single value when the method has one output
+func (abi ABI) Unpack(v interface{}, nameOrSig string, output []byte) error {
+	method, err := abi.Method(nameOrSig)
+	if err != nil {
+		return err
+	}
+	values, err := method.Outputs.UnpackValues(output)
+	if err != nil {
+		return err
+	}
+	return method.Outputs.copy(v, values)
+}
+
+func (arguments Arguments) copy(v interface{}, values []interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("abi: Unpack needs a non-nil pointer,actual:%T", v)
+	}
+	dst := rv.Elem()
+	switch {
+	case dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.Interface:
+		if dst.Len() < len(values) {
+			grown := reflect.MakeSlice(dst.Type(), len(values), len(values))
+			reflect.Copy(grown, dst)
+			dst.Set(grown)
+		}
+		for i, value := range values {
+			elem := dst.Index(i)
+			if !elem.IsNil() && elem.Elem().Kind() == reflect.Ptr {
+				if err := assign(elem.Elem().Elem(), value); err != nil {
+					return fmt.Errorf("abi: output %v: %w", i, err)
+				}
+				continue
+			}
+			elem.Set(reflect.ValueOf(value))
+		}
+		return nil
+	case dst.Kind() == reflect.Struct && dst.Type() != bigIntType.Elem():
+		return arguments.copyStruct(dst, values)
+	}
+	if len(values) != 1 {
+		return fmt.Errorf("abi: cannot unpack %v outputs into %v", len(values), dst.Type())
+	}
+	return assign(dst, values[0])
+}
+
+func (arguments Arguments) copyStruct(dst reflect.Value, values []interface{}) error {
+	named := len(arguments) > 0
+	for _, argument := range arguments {
+		if argument.Name == "" {
+			named = false
+		}
+	}
+	fields := make([]reflect.Value, 0, dst.NumField())
+	for i := 0; i < dst.NumField(); i++ {
+		if dst.Type().Field(i).PkgPath == "" {
+			fields = append(fields, dst.Field(i))
+		}
+	}
+	for i, value := range values {
+		var field reflect.Value
+		if named {
+			field = fieldByName(dst, arguments[i].Name)
+			if !field.IsValid() {
+				return fmt.Errorf("abi: field for output %v not found in %v", arguments[i].Name, dst.Type())
+			}
+		} else {
+			if i >= len(fields) {
+				return fmt.Errorf("abi: %v has less fields than the %v outputs", dst.Type(), len(values))
+			}
+			field = fields[i]
+		}
+		if err := assign(field, value); err != nil {
+			return fmt.Errorf("abi: output %v: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// fieldByName finds the exported field tagged abi:"name", or else the field whose name matches the
+// output name with the leading underscores removed and the first letter capitalized.
+func fieldByName(dst reflect.Value, name string) reflect.Value {
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Type().Field(i)
+		if field.PkgPath == "" && field.Tag.Get("abi") == name {
+			return dst.Field(i)
+		}
+	}
+	name = strings.TrimLeft(name, "_")
+	if name == "" {
+		return reflect.Value{}
+	}
+	goName := strings.ToUpper(name[:1]) + name[1:]
+	if field, ok := dst.Type().FieldByName(goName); ok && field.PkgPath == "" {
+		return dst.FieldByIndex(field.Index)
+	}
+	return reflect.Value{}
+}
@@ -0,0 +1,265 @@
+package abi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/mychain"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+const abiJsonStr = `[
+  {
+    "constant": true,
+    "inputs": [
+      {
+        "name": "b",
+        "type": "bytes"
+      },
+      {
+        "name": "s",
+        "type": "string"
+      }
+    ],
+    "name": "SayHello",
+    "outputs": [
+      {
+        "name": "",
+        "type": "bytes"
+      },
+      {
+        "name": "",
+        "type": "string"
+      }
+    ],
+    "payable": false,
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "constant": true,
+    "inputs": [],
+    "name": "beneficiary",
+    "outputs": [
+      {
+        "name": "",
+        "type": "identity"
+      }
+    ],
+    "payable": false,
+    "stateMutability": "view",
+    "type": "function"
+  },
+  {
+    "inputs": [
+      {
+        "name": "_greeting",
+        "type": "uint256"
+      },
+      {
+        "name": "a",
+        "type": "string"
+      }
+    ],
+    "payable": false,
+    "stateMutability": "nonpayable",
+    "type": "constructor"
+  },
+  {
+    "inputs": [
+      {
+        "name": "owner",
+        "type": "identity"
+      },
+      {
+        "name": "amount",
+        "type": "int"
+      }
+    ],
+    "name": "transfer",
+    "outputs": [
+      {
+        "name": "ok",
+        "type": "bool"
+      },
+      {
+        "name": "_balance",
+        "type": "uint"
+      }
+    ],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  }
+]`
+
+func TestJSON(t *testing.T) {
+	contractAbi, err := JSON(strings.NewReader(abiJsonStr))
+	require.NoError(t, err)
+	require.NotNil(t, contractAbi.Constructor)
+	require.Len(t, contractAbi.Constructor.Inputs, 2)
+
+	method, err := contractAbi.Method("SayHello")
+	require.NoError(t, err)
+	require.Equal(t, "SayHello(bytes,string)", method.Sig())
+	require.Equal(t, `["bytes","string"]`, method.OutTypes())
+	require.True(t, method.Constant)
+
+	method, err = contractAbi.Method("transfer(identity,int)")
+	require.NoError(t, err)
+	require.Equal(t, "transfer(identity,int256)", method.Sig())
+	require.Equal(t, `["bool","uint"]`, method.OutTypes())
+
+	_, err = contractAbi.Method("missing")
+	require.Error(t, err)
+	_, err = JSON(strings.NewReader(`[{"type":"function","name":"f","inputs":[{"type":"address"}]}]`))
+	require.Error(t, err)
+}
+
+func TestPackUnpack_SolidityVarTypes(t *testing.T) {
+	identity := mychain.NewIdentity("rest_biz_test_account")
+	cases := []struct {
+		varType model.SolidityVarType
+		in      interface{}
+		out     interface{}
+	}{
+		{model.Int, big.NewInt(-42), big.NewInt(-42)},
+		{model.Int64, int64(-7), int64(-7)},
+		{model.IntArray, []int{1, -2}, []*big.Int{big.NewInt(1), big.NewInt(-2)}},
+		{model.Int64Array, []int64{3, 4}, []int64{3, 4}},
+		{model.Uint, uint64(1 << 40), new(big.Int).SetUint64(1 << 40)},
+		{model.UintArray, []uint{5}, []*big.Int{big.NewInt(5)}},
+		{model.Bool, true, true},
+		{model.BoolArray, []bool{true, false}, []bool{true, false}},
+		{model.Bytes, []byte("hello"), []byte("hello")},
+		{model.BytesArray, [][]byte{[]byte("a"), []byte("bc")}, [][]byte{[]byte("a"), []byte("bc")}},
+		{model.Identity, identity, identity},
+		{model.Identity, identity.Hex(), identity},
+		{model.IdentityArray, []mychain.Identity{identity}, []mychain.Identity{identity}},
+		{model.String, "我是中国人", "我是中国人"},
+		{model.EncodedBytes, []byte{0, 1, 2}, []byte{0, 1, 2}},
+		{model.ListBytes, [][]byte{[]byte("x")}, [][]byte{[]byte("x")}},
+		{"bytes4", [4]byte{1, 2, 3, 4}, [4]byte{1, 2, 3, 4}},
+		{"uint8[2]", []uint8{1, 2}, [2]uint8{1, 2}},
+		{"string[2]", []string{"a", "b"}, [2]string{"a", "b"}},
+	}
+	for _, c := range cases {
+		typ, err := NewType(string(c.varType))
+		require.NoErrorf(t, err, "type:%v", c.varType)
+		arguments := Arguments{{Type: typ}, {Type: Type{Kind: StringKind, raw: "string"}}}
+		packed, err := arguments.Pack(c.in, "tail")
+		require.NoErrorf(t, err, "type:%v", c.varType)
+		require.Zerof(t, len(packed)%wordSize, "type:%v", c.varType)
+		values, err := arguments.UnpackValues(packed)
+		require.NoErrorf(t, err, "type:%v", c.varType)
+		require.Equalf(t, c.out, values[0], "type:%v", c.varType)
+		require.Equal(t, "tail", values[1])
+	}
+}
+
+func TestPack_Invalid(t *testing.T) {
+	cases := map[string]interface{}{
+		"int8":     300,
+		"uint":     -1,
+		"bool":     "true",
+		"bytes2":   []byte{1, 2, 3},
+		"identity": "00",
+		"uint[2]":  []uint{1},
+		"string":   nil,
+	}
+	for varType, in := range cases {
+		typ, err := NewType(varType)
+		require.NoError(t, err)
+		_, err = Arguments{{Type: typ}}.Pack(in)
+		require.Errorf(t, err, "type:%v in:%v", varType, in)
+	}
+}
+
+func TestUnpack_Targets(t *testing.T) {
+	contractAbi, err := JSON(strings.NewReader(abiJsonStr))
+	require.NoError(t, err)
+	output, err := contractAbi.Methods["SayHello(bytes,string)"].Outputs.Pack([]byte("hello"), "world")
+	require.NoError(t, err)
+
+	sayHelloResp := &[]interface{}{&[]byte{}, new(string)}
+	require.NoError(t, contractAbi.Unpack(sayHelloResp, "SayHello(bytes,string)", output))
+	require.Equal(t, []byte("hello"), *(*sayHelloResp)[0].(*[]byte))
+	require.Equal(t, "world", *(*sayHelloResp)[1].(*string))
+
+	positional := struct {
+		B []byte
+		S string
+	}{}
+	require.NoError(t, contractAbi.Unpack(&positional, "SayHello", output))
+	require.Equal(t, "world", positional.S)
+
+	transfer, err := contractAbi.Method("transfer")
+	require.NoError(t, err)
+	output, err = transfer.Outputs.Pack(true, 99)
+	require.NoError(t, err)
+	named := struct {
+		Balance uint32
+		Done    bool `abi:"ok"`
+	}{}
+	require.NoError(t, contractAbi.Unpack(&named, "transfer", output))
+	require.Equal(t, uint32(99), named.Balance)
+	require.True(t, named.Done)
+	overflow := struct {
+		Balance int8
+		Ok      bool
+	}{}
+	output, err = transfer.Outputs.Pack(true, 1000)
+	require.NoError(t, err)
+	require.Error(t, contractAbi.Unpack(&overflow, "transfer", output))
+
+	identity := mychain.NewIdentity("beneficiary")
+	output, err = contractAbi.Methods["beneficiary()"].Outputs.Pack(identity)
+	require.NoError(t, err)
+	beneficiary := mychain.Identity{}
+	require.NoError(t, contractAbi.Unpack(&beneficiary, "beneficiary", output))
+	require.Equal(t, identity, beneficiary)
+
+	require.Error(t, contractAbi.Unpack(&beneficiary, "beneficiary", output[:16]))
+	require.Error(t, contractAbi.Unpack(beneficiary, "beneficiary", output))
+}
+
+func TestUnpack_ForgedLength(t *testing.T) {
+	for _, varType := range []string{"uint256[]", "string[]", "uint256[2][]"} {
+		typ, err := NewType(varType)
+		require.NoError(t, err)
+		// offset 32 then a length of 0xffffff with no elements behind it
+		output := make([]byte, 2*wordSize)
+		output[wordSize-1] = wordSize
+		output[len(output)-3], output[len(output)-2], output[len(output)-1] = 0xff, 0xff, 0xff
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		_, err = Arguments{{Type: typ}}.UnpackValues(output)
+		runtime.ReadMemStats(&after)
+		require.Errorf(t, err, "type:%v", varType)
+		require.Contains(t, err.Error(), "too short")
+		require.Lessf(t, after.TotalAlloc-before.TotalAlloc, uint64(1<<20), "type:%v allocated before failing", varType)
+	}
+}
+
+func TestEncodeInputParamList(t *testing.T) {
+	contractAbi, err := JSON(strings.NewReader(abiJsonStr))
+	require.NoError(t, err)
+
+	inputParamListStr, err := contractAbi.EncodeInputParamList("SayHello", []byte("hello"), "world")
+	require.NoError(t, err)
+	params := make([]interface{}, 0)
+	require.NoError(t, json.Unmarshal([]byte(inputParamListStr), &params))
+	require.Equal(t, base64.StdEncoding.EncodeToString([]byte("hello")), params[0])
+	require.Equal(t, "world", params[1])
+
+	identity := mychain.NewIdentity("owner")
+	inputParamListStr, err = contractAbi.EncodeInputParamList("transfer", identity, new(big.Int).Lsh(big.NewInt(1), 100))
+	require.NoError(t, err)
+	require.Equal(t, `["`+identity.Hex()+`",1267650600228229401496703205376]`, inputParamListStr)
+
+	_, err = contractAbi.EncodeInputParamList("SayHello", []byte("hello"))
+	require.Error(t, err)
+}
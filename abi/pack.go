@@ -0,0 +1,240 @@
+package abi
+
+import (
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/mychain"
+	"math/big"
+	"reflect"
+)
+
+var (
+	tt256   = new(big.Int).Lsh(big.NewInt(1), 256)
+	tt256m1 = new(big.Int).Sub(tt256, big.NewInt(1))
+)
+
+// normalize checks v against t and converts it to the canonical value of the type: *big.Int for
+// integers, bool, string, []byte for bytes and fixed bytes, mychain.Identity and []interface{} for
+// slices and arrays.
+func (t Type) normalize(v interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(v)
+	for rv.IsValid() && (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) && rv.Type() != bigIntType {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("abi: nil value for %v", t)
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil, fmt.Errorf("abi: nil value for %v", t)
+	}
+
+	switch t.Kind {
+	case IntKind, UintKind:
+		n, ok := toBigInt(rv)
+		if !ok {
+			return nil, fmt.Errorf("abi: cannot use %v as %v", rv.Type(), t)
+		}
+		if !t.fits(n) {
+			return nil, fmt.Errorf("abi: %v overflows %v", n, t)
+		}
+		return n, nil
+	case BoolKind:
+		if rv.Kind() != reflect.Bool {
+			return nil, fmt.Errorf("abi: cannot use %v as %v", rv.Type(), t)
+		}
+		return rv.Bool(), nil
+	case StringKind:
+		if rv.Kind() != reflect.String {
+			return nil, fmt.Errorf("abi: cannot use %v as %v", rv.Type(), t)
+		}
+		return rv.String(), nil
+	case BytesKind, FixedBytesKind:
+		b, ok := toBytes(rv)
+		if !ok {
+			return nil, fmt.Errorf("abi: cannot use %v as %v", rv.Type(), t)
+		}
+		if t.Kind == FixedBytesKind && len(b) > t.Size {
+			return nil, fmt.Errorf("abi: %v bytes overflow %v", len(b), t)
+		}
+		return b, nil
+	case IdentityKind:
+		if rv.Kind() == reflect.String {
+			return mychain.HexToIdentity(rv.String())
+		}
+		b, ok := toBytes(rv)
+		if !ok || len(b) != mychain.IdentityLength {
+			return nil, fmt.Errorf("abi: cannot use %v as %v", rv.Type(), t)
+		}
+		identity := mychain.Identity{}
+		copy(identity[:], b)
+		return identity, nil
+	case SliceKind, ArrayKind:
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, fmt.Errorf("abi: cannot use %v as %v", rv.Type(), t)
+		}
+		if t.Kind == ArrayKind && rv.Len() != t.Size {
+			return nil, fmt.Errorf("abi: %v needs %v elements,actual:%v", t, t.Size, rv.Len())
+		}
+		elems := make([]interface{}, rv.Len())
+		for i := range elems {
+			elem, err := t.Elem.normalize(rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			elems[i] = elem
+		}
+		return elems, nil
+	}
+	return nil, fmt.Errorf("abi: unsupported type %v", t)
+}
+
+// fits reports whether n is in the range of the integer type.
+func (t Type) fits(n *big.Int) bool {
+	if t.Kind == UintKind {
+		return n.Sign() >= 0 && n.BitLen() <= t.Size
+	}
+	if n.Sign() >= 0 {
+		return n.BitLen() < t.Size
+	}
+	// -2^(size-1) is the smallest value
+	return new(big.Int).Add(n, big.NewInt(1)).BitLen() < t.Size
+}
+
+func toBigInt(rv reflect.Value) (*big.Int, bool) {
+	if rv.Type() == bigIntType {
+		if rv.IsNil() {
+			return nil, false
+		}
+		return new(big.Int).Set(rv.Interface().(*big.Int)), true
+	}
+	if rv.Type() == bigIntType.Elem() {
+		n := rv.Interface().(big.Int)
+		return new(big.Int).Set(&n), true
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(rv.Uint()), true
+	}
+	return nil, false
+}
+
+func toBytes(rv reflect.Value) ([]byte, bool) {
+	if (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) || rv.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, false
+	}
+	b := make([]byte, rv.Len())
+	reflect.Copy(reflect.ValueOf(b), rv)
+	return b, true
+}
+
+// packTuple encodes the normalized values as an abi tuple.
+func packTuple(types []Type, values []interface{}) ([]byte, error) {
+	headLen := 0
+	for _, t := range types {
+		headLen += t.headSize()
+	}
+	var head, tail []byte
+	for i, t := range types {
+		packed, err := t.pack(values[i])
+		if err != nil {
+			return nil, err
+		}
+		if t.isDynamic() {
+			head = append(head, packUint(uint64(headLen+len(tail)))...)
+			tail = append(tail, packed...)
+		} else {
+			head = append(head, packed...)
+		}
+	}
+	return append(head, tail...), nil
+}
+
+// pack encodes a normalized value, dynamic values are encoded as they appear in the tail.
+func (t Type) pack(v interface{}) ([]byte, error) {
+	switch t.Kind {
+	case IntKind, UintKind:
+		n := v.(*big.Int)
+		if n.Sign() < 0 {
+			n = new(big.Int).And(n, tt256m1)
+		}
+		return leftPad(n.Bytes()), nil
+	case BoolKind:
+		if v.(bool) {
+			return packUint(1), nil
+		}
+		return packUint(0), nil
+	case StringKind:
+		return packDynamicBytes([]byte(v.(string))), nil
+	case BytesKind:
+		return packDynamicBytes(v.([]byte)), nil
+	case FixedBytesKind:
+		return rightPad(v.([]byte)), nil
+	case IdentityKind:
+		identity := v.(mychain.Identity)
+		return identity[:], nil
+	case SliceKind, ArrayKind:
+		elems := v.([]interface{})
+		types := make([]Type, len(elems))
+		for i := range types {
+			types[i] = *t.Elem
+		}
+		packed, err := packTuple(types, elems)
+		if err != nil {
+			return nil, err
+		}
+		if t.Kind == SliceKind {
+			return append(packUint(uint64(len(elems))), packed...), nil
+		}
+		return packed, nil
+	}
+	return nil, fmt.Errorf("abi: unsupported type %v", t)
+}
+
+// jsonValue converts a normalized value to the form the rest server expects in inputParamListStr:
+// integers as json numbers, bytes base64 encoded and identities hex encoded.
+func (t Type) jsonValue(v interface{}) interface{} {
+	switch t.Kind {
+	case IdentityKind:
+		return v.(mychain.Identity).Hex()
+	case FixedBytesKind:
+		b := v.([]byte)
+		return rightPad(b)[:t.Size]
+	case SliceKind, ArrayKind:
+		elems := v.([]interface{})
+		values := make([]interface{}, len(elems))
+		for i, elem := range elems {
+			values[i] = t.Elem.jsonValue(elem)
+		}
+		return values
+	}
+	return v
+}
+
+func packUint(n uint64) []byte {
+	return leftPad(new(big.Int).SetUint64(n).Bytes())
+}
+
+func packDynamicBytes(b []byte) []byte {
+	packed := packUint(uint64(len(b)))
+	for i := 0; i < len(b); i += wordSize {
+		end := i + wordSize
+		if end > len(b) {
+			end = len(b)
+		}
+		packed = append(packed, rightPad(b[i:end])...)
+	}
+	return packed
+}
+
+func leftPad(b []byte) []byte {
+	padded := make([]byte, wordSize)
+	copy(padded[wordSize-len(b):], b)
+	return padded
+}
+
+func rightPad(b []byte) []byte {
+	padded := make([]byte, wordSize)
+	copy(padded, b)
+	return padded
+}
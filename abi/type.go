@@ -0,0 +1,225 @@
+package abi
+
+import (
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/mychain"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// wordSize is the size of an abi slot.
+const wordSize = 32
+
+type Kind int
+
+const (
+	IntKind Kind = iota
+	UintKind
+	BoolKind
+	StringKind
+	BytesKind
+	FixedBytesKind
+	IdentityKind
+	SliceKind
+	ArrayKind
+)
+
+// Type is a parsed solidity type. Size is the bit size of integers, the byte size of fixed bytes and
+// the length of fixed arrays.
+type Type struct {
+	Kind Kind
+	Size int
+	Elem *Type
+	raw  string
+}
+
+// NewType parses a solidity type. Besides the abi json types it accepts every model.SolidityVarType:
+// int and uint are 256 bits, encodedbytes is bytes and list(T) is T[].
+func NewType(t string) (Type, error) {
+	t = strings.TrimSpace(t)
+	if strings.HasSuffix(t, "]") {
+		i := strings.LastIndex(t, "[")
+		if i < 0 {
+			return Type{}, fmt.Errorf("abi: invalid type %v", t)
+		}
+		elem, err := NewType(t[:i])
+		if err != nil {
+			return Type{}, err
+		}
+		if dim := t[i+1 : len(t)-1]; dim != "" {
+			n, err := strconv.Atoi(dim)
+			if err != nil || n <= 0 {
+				return Type{}, fmt.Errorf("abi: invalid array length in %v", t)
+			}
+			return Type{Kind: ArrayKind, Size: n, Elem: &elem, raw: t}, nil
+		}
+		return Type{Kind: SliceKind, Elem: &elem, raw: t}, nil
+	}
+	if strings.HasPrefix(t, "list(") && strings.HasSuffix(t, ")") {
+		elem, err := NewType(t[len("list(") : len(t)-1])
+		if err != nil {
+			return Type{}, err
+		}
+		return Type{Kind: SliceKind, Elem: &elem, raw: t}, nil
+	}
+
+	switch t {
+	case string(model.Bool):
+		return Type{Kind: BoolKind, raw: t}, nil
+	case string(model.String):
+		return Type{Kind: StringKind, raw: t}, nil
+	case string(model.Bytes), string(model.EncodedBytes):
+		return Type{Kind: BytesKind, raw: t}, nil
+	case string(model.Identity):
+		return Type{Kind: IdentityKind, Size: mychain.IdentityLength, raw: t}, nil
+	case string(model.Int):
+		return Type{Kind: IntKind, Size: 256, raw: t}, nil
+	case string(model.Uint):
+		return Type{Kind: UintKind, Size: 256, raw: t}, nil
+	}
+	for _, prefix := range []string{"uint", "int", "bytes"} {
+		if !strings.HasPrefix(t, prefix) {
+			continue
+		}
+		n, err := strconv.Atoi(t[len(prefix):])
+		if err != nil {
+			break
+		}
+		if prefix == "bytes" {
+			if n < 1 || n > 32 {
+				return Type{}, fmt.Errorf("abi: invalid fixed bytes size in %v", t)
+			}
+			return Type{Kind: FixedBytesKind, Size: n, raw: t}, nil
+		}
+		if n < 8 || n > 256 || n%8 != 0 {
+			return Type{}, fmt.Errorf("abi: invalid integer size in %v", t)
+		}
+		if prefix == "int" {
+			return Type{Kind: IntKind, Size: n, raw: t}, nil
+		}
+		return Type{Kind: UintKind, Size: n, raw: t}, nil
+	}
+	return Type{}, fmt.Errorf("abi: unsupported type %v", t)
+}
+
+// String returns the type as written in the abi.
+func (t Type) String() string {
+	return t.raw
+}
+
+// canonical returns the type name used in method signatures, int and uint are spelled out with their size.
+func (t Type) canonical() string {
+	switch t.Kind {
+	case IntKind:
+		return fmt.Sprintf("int%v", t.Size)
+	case UintKind:
+		return fmt.Sprintf("uint%v", t.Size)
+	case BoolKind:
+		return "bool"
+	case StringKind:
+		return "string"
+	case BytesKind:
+		return "bytes"
+	case FixedBytesKind:
+		return fmt.Sprintf("bytes%v", t.Size)
+	case IdentityKind:
+		return "identity"
+	case SliceKind:
+		return t.Elem.canonical() + "[]"
+	case ArrayKind:
+		return fmt.Sprintf("%v[%v]", t.Elem.canonical(), t.Size)
+	}
+	return t.raw
+}
+
+// SolidityVarType returns the name the rest server expects in outTypes: 256 bit integers are int and
+// uint, everything else keeps its canonical name.
+func (t Type) SolidityVarType() model.SolidityVarType {
+	switch t.Kind {
+	case IntKind:
+		if t.Size == 256 {
+			return model.Int
+		}
+	case UintKind:
+		if t.Size == 256 {
+			return model.Uint
+		}
+	case SliceKind:
+		return model.SolidityVarType(string(t.Elem.SolidityVarType()) + "[]")
+	case ArrayKind:
+		return model.SolidityVarType(fmt.Sprintf("%v[%v]", t.Elem.SolidityVarType(), t.Size))
+	}
+	return model.SolidityVarType(t.canonical())
+}
+
+// isDynamic reports whether the value is stored in the tail of its tuple.
+func (t Type) isDynamic() bool {
+	switch t.Kind {
+	case StringKind, BytesKind, SliceKind:
+		return true
+	case ArrayKind:
+		return t.Elem.isDynamic()
+	}
+	return false
+}
+
+// headSize is the size taken by the type in the head of its tuple.
+func (t Type) headSize() int {
+	if t.Kind == ArrayKind && !t.isDynamic() {
+		return t.Size * t.Elem.headSize()
+	}
+	return wordSize
+}
+
+var (
+	bigIntType   = reflect.TypeOf(&big.Int{})
+	identityType = reflect.TypeOf(mychain.Identity{})
+)
+
+// goType is the type of the values decoded for t.
+func (t Type) goType() reflect.Type {
+	switch t.Kind {
+	case IntKind:
+		switch t.Size {
+		case 8:
+			return reflect.TypeOf(int8(0))
+		case 16:
+			return reflect.TypeOf(int16(0))
+		case 32:
+			return reflect.TypeOf(int32(0))
+		case 64:
+			return reflect.TypeOf(int64(0))
+		}
+		return bigIntType
+	case UintKind:
+		switch t.Size {
+		case 8:
+			return reflect.TypeOf(uint8(0))
+		case 16:
+			return reflect.TypeOf(uint16(0))
+		case 32:
+			return reflect.TypeOf(uint32(0))
+		case 64:
+			return reflect.TypeOf(uint64(0))
+		}
+		return bigIntType
+	case BoolKind:
+		return reflect.TypeOf(false)
+	case StringKind:
+		return reflect.TypeOf("")
+	case BytesKind:
+		return reflect.TypeOf([]byte{})
+	case FixedBytesKind:
+		return reflect.ArrayOf(t.Size, reflect.TypeOf(byte(0)))
+	case IdentityKind:
+		return identityType
+	case SliceKind:
+		return reflect.SliceOf(t.Elem.goType())
+	case ArrayKind:
+		return reflect.ArrayOf(t.Size, t.Elem.goType())
+	}
+	return reflect.TypeOf((*interface{})(nil)).Elem()
+}
@@ -0,0 +1,210 @@
+package abi
+
+import (
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/mychain"
+	"math/big"
+	"reflect"
+)
+
+const maxDecodeLength = 1 << 24
+
+// unpackTuple decodes an abi tuple of types from data.
+func unpackTuple(types []Type, data []byte) ([]interface{}, error) {
+	values := make([]interface{}, len(types))
+	pos := 0
+	for i, t := range types {
+		if pos+t.headSize() > len(data) {
+			return nil, fmt.Errorf("abi: output too short to decode %v", t)
+		}
+		var err error
+		if t.isDynamic() {
+			offset, err := readLength(data[pos : pos+wordSize])
+			if err != nil {
+				return nil, err
+			}
+			if offset > len(data) {
+				return nil, fmt.Errorf("abi: offset %v of %v out of range", offset, t)
+			}
+			values[i], err = t.unpackDynamic(data[offset:])
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			values[i], err = t.unpackStatic(data[pos : pos+t.headSize()])
+			if err != nil {
+				return nil, err
+			}
+		}
+		pos += t.headSize()
+	}
+	return values, nil
+}
+
+func (t Type) unpackDynamic(data []byte) (interface{}, error) {
+	if t.Kind == ArrayKind {
+		return t.unpackElems(t.Size, data)
+	}
+	if len(data) < wordSize {
+		return nil, fmt.Errorf("abi: output too short to decode %v", t)
+	}
+	n, err := readLength(data[:wordSize])
+	if err != nil {
+		return nil, err
+	}
+	data = data[wordSize:]
+	switch t.Kind {
+	case StringKind, BytesKind:
+		if n > len(data) {
+			return nil, fmt.Errorf("abi: output too short to decode %v of length %v", t, n)
+		}
+		if t.Kind == StringKind {
+			return string(data[:n]), nil
+		}
+		b := make([]byte, n)
+		copy(b, data[:n])
+		return b, nil
+	case SliceKind:
+		return t.unpackElems(n, data)
+	}
+	return nil, fmt.Errorf("abi: unsupported type %v", t)
+}
+
+// unpackElems decodes n elements of a slice or array into a value of t's go type.
+func (t Type) unpackElems(n int, data []byte) (interface{}, error) {
+	// checked before allocating so that a forged length cannot exhaust memory
+	if n*t.Elem.headSize() > len(data) {
+		return nil, fmt.Errorf("abi: output too short to decode %v of length %v", t, n)
+	}
+	types := make([]Type, n)
+	for i := range types {
+		types[i] = *t.Elem
+	}
+	elems, err := unpackTuple(types, data)
+	if err != nil {
+		return nil, err
+	}
+	var rv reflect.Value
+	if t.Kind == SliceKind {
+		rv = reflect.MakeSlice(t.goType(), n, n)
+	} else {
+		rv = reflect.New(t.goType()).Elem()
+	}
+	for i, elem := range elems {
+		rv.Index(i).Set(reflect.ValueOf(elem))
+	}
+	return rv.Interface(), nil
+}
+
+func (t Type) unpackStatic(word []byte) (interface{}, error) {
+	switch t.Kind {
+	case IntKind, UintKind:
+		n := new(big.Int).SetBytes(word)
+		if t.Kind == IntKind && word[0]&0x80 != 0 {
+			n.Sub(n, tt256)
+		}
+		if !t.fits(n) {
+			return nil, fmt.Errorf("abi: %v overflows %v", n, t)
+		}
+		switch v := reflect.New(t.goType()).Elem(); v.Kind() {
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			v.SetInt(n.Int64())
+			return v.Interface(), nil
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			v.SetUint(n.Uint64())
+			return v.Interface(), nil
+		}
+		return n, nil
+	case BoolKind:
+		for _, b := range word[:wordSize-1] {
+			if b != 0 {
+				return nil, fmt.Errorf("abi: invalid bool")
+			}
+		}
+		switch word[wordSize-1] {
+		case 0:
+			return false, nil
+		case 1:
+			return true, nil
+		}
+		return nil, fmt.Errorf("abi: invalid bool")
+	case FixedBytesKind:
+		v := reflect.New(t.goType()).Elem()
+		reflect.Copy(v, reflect.ValueOf(word[:t.Size]))
+		return v.Interface(), nil
+	case IdentityKind:
+		identity := mychain.Identity{}
+		copy(identity[:], word)
+		return identity, nil
+	case ArrayKind:
+		return t.unpackElems(t.Size, word)
+	}
+	return nil, fmt.Errorf("abi: unsupported type %v", t)
+}
+
+func readLength(word []byte) (int, error) {
+	n := new(big.Int).SetBytes(word)
+	if !n.IsInt64() || n.Int64() > maxDecodeLength {
+		return 0, fmt.Errorf("abi: length or offset %v too large", n)
+	}
+	return int(n.Int64()), nil
+}
+
+// assign stores the decoded value src into dst, converting between integer types, slices and arrays
+// and allocating pointers as needed.
+func assign(dst reflect.Value, src interface{}) error {
+	sv := reflect.ValueOf(src)
+	if sv.Type().AssignableTo(dst.Type()) {
+		dst.Set(sv)
+		return nil
+	}
+	switch {
+	case dst.Kind() == reflect.Ptr:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return assign(dst.Elem(), src)
+	case dst.Type() == bigIntType.Elem():
+		n, ok := toBigInt(sv)
+		if !ok {
+			break
+		}
+		dst.Set(reflect.ValueOf(*n))
+		return nil
+	}
+
+	if n, ok := toBigInt(sv); ok {
+		switch dst.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if !n.IsInt64() || dst.OverflowInt(n.Int64()) {
+				return fmt.Errorf("abi: %v overflows %v", n, dst.Type())
+			}
+			dst.SetInt(n.Int64())
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if !n.IsUint64() || dst.OverflowUint(n.Uint64()) {
+				return fmt.Errorf("abi: %v overflows %v", n, dst.Type())
+			}
+			dst.SetUint(n.Uint64())
+			return nil
+		}
+	}
+	if (sv.Kind() == reflect.Slice || sv.Kind() == reflect.Array) && (dst.Kind() == reflect.Slice || dst.Kind() == reflect.Array) {
+		if dst.Kind() == reflect.Slice {
+			dst.Set(reflect.MakeSlice(dst.Type(), sv.Len(), sv.Len()))
+		} else if dst.Len() < sv.Len() {
+			return fmt.Errorf("abi: cannot assign %v elements to %v", sv.Len(), dst.Type())
+		}
+		for i := 0; i < sv.Len(); i++ {
+			if err := assign(dst.Index(i), sv.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if sv.Type().ConvertibleTo(dst.Type()) && sv.Kind() == dst.Kind() {
+		dst.Set(sv.Convert(dst.Type()))
+		return nil
+	}
+	return fmt.Errorf("abi: cannot assign %v to %v", sv.Type(), dst.Type())
+}
@@ -0,0 +1,52 @@
+package abi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CheckUpgrade returns an error listing the changes from registered to upgraded that break callers of
+// the registered contract: a removed method, a method whose outputs changed or that is no longer
+// constant, and a removed event or an event whose params changed. New methods, new events and a
+// changed constructor are compatible.
+func CheckUpgrade(registered, upgraded ABI) error {
+	var changes []string
+	for sig, method := range registered.Methods {
+		next, ok := upgraded.Methods[sig]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("method %v is removed", sig))
+		case !sameArguments(method.Outputs, next.Outputs):
+			changes = append(changes, fmt.Sprintf("outputs of method %v changed from %v to %v", sig, method.OutTypes(), next.OutTypes()))
+		case method.Constant && !next.Constant:
+			changes = append(changes, fmt.Sprintf("method %v is no longer constant", sig))
+		}
+	}
+	for name, event := range registered.Events {
+		next, ok := upgraded.Events[name]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("event %v is removed", name))
+		case !sameArguments(event.Inputs, next.Inputs) || event.Anonymous != next.Anonymous:
+			changes = append(changes, fmt.Sprintf("event %v changed", name))
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	sort.Strings(changes)
+	return fmt.Errorf("abi: upgrade is not compatible,%v", strings.Join(changes, ";"))
+}
+
+func sameArguments(arguments, others Arguments) bool {
+	if len(arguments) != len(others) {
+		return false
+	}
+	for i, argument := range arguments {
+		if argument.Type.canonical() != others[i].Type.canonical() || argument.Indexed != others[i].Indexed {
+			return false
+		}
+	}
+	return true
+}
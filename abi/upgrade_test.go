@@ -0,0 +1,47 @@
+package abi
+
+import (
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func mustJSON(t *testing.T, abiJson string) ABI {
+	contractAbi, err := JSON(strings.NewReader(abiJson))
+	require.NoError(t, err)
+	return contractAbi
+}
+
+func TestCheckUpgrade(t *testing.T) {
+	registered := mustJSON(t, abiJsonStr)
+	require.NoError(t, CheckUpgrade(registered, registered))
+
+	upgraded := mustJSON(t, strings.Replace(abiJsonStr, `"type": "constructor"`, `"type": "constructor"},
+  {"type": "function", "name": "version", "inputs": [], "outputs": [{"name": "", "type": "uint8"}]},
+  {"type": "event", "name": "Upgraded", "inputs": [{"name": "v", "type": "uint8", "indexed": true}]`, 1))
+	require.Len(t, upgraded.Methods, 4)
+	require.NoError(t, CheckUpgrade(registered, upgraded), "adding methods and events is compatible")
+	require.Error(t, CheckUpgrade(upgraded, registered))
+
+	cases := map[string]string{
+		"removed": strings.Replace(abiJsonStr, `"name": "beneficiary"`, `"name": "owner"`, 1),
+		"inputs":  strings.Replace(abiJsonStr, `"type": "int"`, `"type": "int64"`, 1),
+		"outputs": strings.Replace(abiJsonStr, `"type": "uint"`, `"type": "uint128"`, 1),
+	}
+	for name, abiJson := range cases {
+		require.Errorf(t, CheckUpgrade(registered, mustJSON(t, abiJson)), "case:%v", name)
+	}
+
+	notConstant := mustJSON(t, abiJsonStr)
+	method := notConstant.Methods["SayHello(bytes,string)"]
+	method.Constant = false
+	notConstant.Methods["SayHello(bytes,string)"] = method
+	require.Error(t, CheckUpgrade(registered, notConstant))
+
+	event := upgraded.Events["Upgraded"]
+	event.Inputs = Arguments{{Name: "v", Type: event.Inputs[0].Type}}
+	changed := mustJSON(t, abiJsonStr)
+	changed.Methods = upgraded.Methods
+	changed.Events["Upgraded"] = event
+	require.Error(t, CheckUpgrade(upgraded, changed), "an event param that is no longer indexed is not compatible")
+}
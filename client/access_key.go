@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+)
+
+func (client *RestClient) ApplyKey(bizid, tenantId, publicKeyPem string) (model.AccessKey, error) {
+	return client.ApplyKeyWithContext(context.Background(), bizid, tenantId, publicKeyPem)
+}
+
+// ApplyKeyWithContext registers the pem encoded public key publicKeyPem as a new access key of tenantId.
+func (client *RestClient) ApplyKeyWithContext(ctx context.Context, bizid, tenantId, publicKeyPem string) (model.AccessKey, error) {
+	if block, _ := pem.Decode([]byte(publicKeyPem)); block == nil {
+		return model.AccessKey{}, response.NewValidationError(fmt.Sprintf("%v method must has pem encoded public key", model.APPLYKEY))
+	}
+	accessKey := model.AccessKey{}
+	err := client.chainCallForBizResult(ctx, model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId: client.RestClientProperties.AccessId,
+			BizId:    bizid,
+			Method:   model.APPLYKEY,
+		},
+		TenantId:       tenantId,
+		ApplyAccessKey: publicKeyPem,
+	}, &accessKey)
+	return accessKey, err
+}
+
+func (client *RestClient) ResetApplyKey(bizid, tenantId, accessId, publicKeyPem string) (model.AccessKey, error) {
+	return client.ResetApplyKeyWithContext(context.Background(), bizid, tenantId, accessId, publicKeyPem)
+}
+
+// ResetApplyKeyWithContext replaces the public key of the access key accessId with publicKeyPem, requests
+// signed with the previous key are refused afterwards.
+func (client *RestClient) ResetApplyKeyWithContext(ctx context.Context, bizid, tenantId, accessId, publicKeyPem string) (model.AccessKey, error) {
+	if block, _ := pem.Decode([]byte(publicKeyPem)); block == nil {
+		return model.AccessKey{}, response.NewValidationError(fmt.Sprintf("%v method must has pem encoded public key", model.RESETAPPLYKEY))
+	}
+	accessKey := model.AccessKey{}
+	err := client.chainCallForBizResult(ctx, model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId:   client.RestClientProperties.AccessId,
+			BizId:      bizid,
+			Method:     model.RESETAPPLYKEY,
+			RequestStr: accessId,
+		},
+		TenantId:       tenantId,
+		ApplyAccessKey: publicKeyPem,
+	}, &accessKey)
+	return accessKey, err
+}
+
+func (client *RestClient) QueryAccessList(bizid, tenantId string) ([]model.AccessKey, error) {
+	return client.QueryAccessListWithContext(context.Background(), bizid, tenantId)
+}
+
+// QueryAccessListWithContext lists the access keys of tenantId, or of every tenant the caller may see
+// when tenantId is empty.
+func (client *RestClient) QueryAccessListWithContext(ctx context.Context, bizid, tenantId string) ([]model.AccessKey, error) {
+	accessKeys := make([]model.AccessKey, 0)
+	err := client.chainCallForBizResult(ctx, model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId: client.RestClientProperties.AccessId,
+			BizId:    bizid,
+			Method:   model.QUERYACCESSLIST,
+		},
+		TenantId: tenantId,
+	}, &accessKeys)
+	return accessKeys, err
+}
+
+func (client *RestClient) QueryTenantKmsList(bizid, tenantId string) ([]model.KmsKey, error) {
+	return client.QueryTenantKmsListWithContext(context.Background(), bizid, tenantId)
+}
+
+// QueryTenantKmsListWithContext lists the kms keys of tenantId.
+func (client *RestClient) QueryTenantKmsListWithContext(ctx context.Context, bizid, tenantId string) ([]model.KmsKey, error) {
+	kmsKeys := make([]model.KmsKey, 0)
+	err := client.chainCallForBizResult(ctx, model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId: client.RestClientProperties.AccessId,
+			BizId:    bizid,
+			Method:   model.QUERYTENANTKMSLIST,
+		},
+		TenantId: tenantId,
+	}, &kmsKeys)
+	return kmsKeys, err
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func newTestPublicKeyPem(t *testing.T) string {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	publicKey, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}))
+}
+
+func TestApplyKeyAndResetApplyKey(t *testing.T) {
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	publicKeyPem := newTestPublicKeyPem(t)
+	accessKey, err := restClient.ApplyKey(RestBizTestBizID, RestBizTestTenantID, publicKeyPem)
+	require.NoError(t, err)
+	require.NotEmpty(t, accessKey.AccessId)
+	require.Equal(t, RestBizTestTenantID, accessKey.TenantId)
+	require.Equal(t, model.AccessKeyEnabled, accessKey.Status)
+
+	resetPem := newTestPublicKeyPem(t)
+	reset, err := restClient.ResetApplyKey(RestBizTestBizID, RestBizTestTenantID, accessKey.AccessId, resetPem)
+	require.NoError(t, err)
+	require.Equal(t, accessKey.AccessId, reset.AccessId)
+	require.Equal(t, resetPem, reset.PublicKey)
+
+	accessKeys, err := restClient.QueryAccessList(RestBizTestBizID, RestBizTestTenantID)
+	require.NoError(t, err)
+	require.Len(t, accessKeys, 1)
+	require.Equal(t, reset, accessKeys[0])
+
+	accessKeys, err = restClient.QueryAccessList(RestBizTestBizID, "other_tenant")
+	require.NoError(t, err)
+	require.Empty(t, accessKeys)
+
+	_, err = restClient.ResetApplyKey(RestBizTestBizID, RestBizTestTenantID, "missing_access_id", resetPem)
+	require.Truef(t, errors.Is(err, response.ErrNotFound), "expect not found err:%+v", err)
+
+	_, err = restClient.ApplyKey(RestBizTestBizID, RestBizTestTenantID, "not a pem key")
+	require.Truef(t, errors.Is(err, response.ErrValidation), "expect validation err:%+v", err)
+	_, err = restClient.ResetApplyKey(RestBizTestBizID, RestBizTestTenantID, accessKey.AccessId, "")
+	require.Truef(t, errors.Is(err, response.ErrValidation), "expect validation err:%+v", err)
+	require.Equal(t, 2, server.Calls(model.RESETAPPLYKEY))
+}
+
+func TestQueryTenantKmsList(t *testing.T) {
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	kmsKeys, err := restClient.QueryTenantKmsList(RestBizTestBizID, RestBizTestTenantID)
+	require.NoError(t, err)
+	require.Empty(t, kmsKeys)
+
+	account := fmt.Sprintf("test_account_%v", uuid.New().String())
+	orderId := fmt.Sprintf("order_%v", uuid.New().String())
+	baseResp, err := restClient.CreateAccountWithKmsId(RestBizTestBizID, orderId, account, RestBizTestTenantID, RestBizTestKmsID)
+	require.Truef(t, err == nil && baseResp.Success && baseResp.Code == "200", "no succ resp baseResp:%+v err:%+v", baseResp, err)
+
+	kmsKeys, err = restClient.QueryTenantKmsList(RestBizTestBizID, RestBizTestTenantID)
+	require.NoError(t, err)
+	require.Len(t, kmsKeys, 1)
+	require.Equal(t, RestBizTestKmsID, kmsKeys[0].MykmsKeyId)
+	require.Equal(t, RestBizTestTenantID, kmsKeys[0].TenantId)
+	require.Equal(t, account, kmsKeys[0].Account)
+	require.Equal(t, model.AccessKeyEnabled, kmsKeys[0].Status)
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/mychain"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+)
+
+// AccountStatusResult reports a freeze or unfreeze: the transaction hash, its receipt and the account
+// queried once the receipt is done.
+type AccountStatusResult struct {
+	Hash    string
+	Receipt mychain.TransactionReceipt
+	Account mychain.Account
+}
+
+func (client *RestClient) FreezeAccount(bizid, orderId, account, tenantId, kmsId, freezeAccount string, opts WaitOptions) (AccountStatusResult, error) {
+	return client.FreezeAccountWithContext(context.Background(), bizid, orderId, account, tenantId, kmsId, freezeAccount, opts)
+}
+
+// FreezeAccountWithContext freezes freezeAccount on behalf of account, waits for the receipt and queries
+// freezeAccount. An error is returned if the receipt fails or the account is not frozen afterwards.
+func (client *RestClient) FreezeAccountWithContext(ctx context.Context, bizid, orderId, account, tenantId, kmsId, freezeAccount string, opts WaitOptions) (AccountStatusResult, error) {
+	return client.setAccountStatus(ctx, model.FREEZEACCOUNTASYN, mychain.AccountFreeze, bizid, orderId, account, tenantId, kmsId, freezeAccount, opts)
+}
+
+func (client *RestClient) UnfreezeAccount(bizid, orderId, account, tenantId, kmsId, unfreezeAccount string, opts WaitOptions) (AccountStatusResult, error) {
+	return client.UnfreezeAccountWithContext(context.Background(), bizid, orderId, account, tenantId, kmsId, unfreezeAccount, opts)
+}
+
+// UnfreezeAccountWithContext unfreezes unfreezeAccount on behalf of account, waits for the receipt and
+// queries unfreezeAccount. An error is returned if the receipt fails or the account is not normal afterwards.
+func (client *RestClient) UnfreezeAccountWithContext(ctx context.Context, bizid, orderId, account, tenantId, kmsId, unfreezeAccount string, opts WaitOptions) (AccountStatusResult, error) {
+	return client.setAccountStatus(ctx, model.UNFREEZEACCOUNTASYN, mychain.AccountNormal, bizid, orderId, account, tenantId, kmsId, unfreezeAccount, opts)
+}
+
+func (client *RestClient) setAccountStatus(ctx context.Context, method model.Method, expected mychain.AccountStatus, bizid, orderId, account, tenantId, kmsId, toAccount string, opts WaitOptions) (AccountStatusResult, error) {
+	callRestBizParam := model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId: client.RestClientProperties.AccessId,
+			BizId:    bizid,
+			Method:   method,
+		},
+		OrderId:    orderId,
+		Account:    account,
+		ToAccount:  toAccount,
+		TenantId:   tenantId,
+		MykmsKeyId: kmsId,
+	}
+	baseResp, err := client.ChainCallForBizWithContext(ctx, callRestBizParam)
+	if err != nil {
+		return AccountStatusResult{}, err
+	}
+	if !baseResp.Success || baseResp.Code != model.ServiceSuccess {
+		return AccountStatusResult{}, fmt.Errorf("%v failed,err:%w", method, response.NewCodeError(baseResp.Code, baseResp.Data, 0))
+	}
+	statusResult := AccountStatusResult{Hash: baseResp.Data}
+	result, err := client.WaitForReceiptWithContext(ctx, bizid, statusResult.Hash, opts)
+	if err != nil {
+		return statusResult, err
+	}
+	if result.State != WaitStateDone {
+		return statusResult, fmt.Errorf("query receipt failed,hash:%v err:%w", statusResult.Hash, result.Resp.Err())
+	}
+	if err := json.Unmarshal([]byte(result.Resp.Data), &statusResult.Receipt); err != nil {
+		return statusResult, err
+	}
+	if statusResult.Receipt.Result != 0 {
+		return statusResult, fmt.Errorf("%w,hash:%v result:%v", response.ErrTxFailed, statusResult.Hash, statusResult.Receipt.Result)
+	}
+
+	statusResult.Account, err = client.queryAccount(ctx, bizid, toAccount)
+	if err != nil {
+		return statusResult, err
+	}
+	if statusResult.Account.Status != expected {
+		return statusResult, fmt.Errorf("account %v is %v after %v", toAccount, statusResult.Account.Status, method)
+	}
+	return statusResult, nil
+}
+
+// queryAccount queries account and parses the returned account.
+func (client *RestClient) queryAccount(ctx context.Context, bizid, account string) (mychain.Account, error) {
+	baseResp, err := client.QueryAccountWithContext(ctx, bizid, account)
+	if err != nil {
+		return mychain.Account{}, err
+	}
+	if !baseResp.Success || baseResp.Code != model.ServiceSuccess {
+		return mychain.Account{}, fmt.Errorf("query account failed,account:%v err:%w", account, response.NewCodeError(baseResp.Code, baseResp.Data, 0))
+	}
+	queried := mychain.Account{}
+	if err := json.Unmarshal([]byte(baseResp.Data), &queried); err != nil {
+		return mychain.Account{}, err
+	}
+	return queried, nil
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/client/internal/resttest"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/mychain"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestFreezeAndUnfreezeAccount(t *testing.T) {
+	server, restClient := newTestRestClient(t, resttest.WithPendingStates(1, 1))
+	defer server.Close()
+	target := fmt.Sprintf("test_freeze_account_%v", uuid.New().String())
+	server.CreateAccount(target, RestBizTestKmsID)
+
+	orderId := fmt.Sprintf("order_%v", uuid.New().String())
+	result, err := restClient.FreezeAccount(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, target, WaitOptions{})
+	require.NoError(t, err)
+	require.Equal(t, mychain.AccountFreeze, result.Account.Status)
+	require.Equal(t, mychain.NewIdentity(target).Hex(), result.Account.Id)
+	tx, ok := server.Transaction(result.Hash)
+	require.True(t, ok)
+	require.Equal(t, target, tx.Param.ToAccount)
+	account, ok := server.Account(target)
+	require.True(t, ok)
+	require.Equal(t, 1, account.Status)
+
+	orderId = fmt.Sprintf("order_%v", uuid.New().String())
+	result, err = restClient.UnfreezeAccount(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, target, WaitOptions{})
+	require.NoError(t, err)
+	require.Equal(t, mychain.AccountNormal, result.Account.Status)
+	require.Equal(t, 2, server.Calls(model.QUERYACCOUNT))
+}
+
+func TestFreezeAccount_Failures(t *testing.T) {
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	orderId := fmt.Sprintf("order_%v", uuid.New().String())
+	_, err := restClient.FreezeAccount(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, "", WaitOptions{})
+	require.Truef(t, errors.Is(err, response.ErrValidation), "expect validation err:%+v", err)
+
+	_, err = restClient.FreezeAccount(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, "missing_account", WaitOptions{})
+	require.Error(t, err)
+	require.Zero(t, server.Calls(model.QUERYACCOUNT))
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+)
+
+// adminMethods are refused by clients built without WithAdmin.
+var adminMethods = map[model.Method]bool{
+	model.FROZENTENANT:             true,
+	model.UNFROZENTENANT:           true,
+	model.REGISTERBLOCKCHAINCONFIG: true,
+	model.DEPOSITWITHADMIN:         true,
+	model.INVITEUSER:               true,
+	model.NEWCHAIN:                 true,
+}
+
+func isAdminMethod(method model.Method) bool {
+	return adminMethods[method]
+}
+
+// AdminClient sends the tenant, chain and consortium administration methods, get it from a client built
+// WithAdmin.
+type AdminClient struct {
+	client *RestClient
+}
+
+// Admin returns the admin methods of the client, an error wrapping response.ErrValidation is
+// returned unless it was built WithAdmin.
+func (client *RestClient) Admin() (*AdminClient, error) {
+	if !client.admin {
+		return nil, response.NewValidationError("admin methods need a client built with WithAdmin")
+	}
+	return &AdminClient{client: client}, nil
+}
+
+func (admin *AdminClient) FreezeTenant(bizid, tenantId string) error {
+	return admin.FreezeTenantWithContext(context.Background(), bizid, tenantId)
+}
+
+// FreezeTenantWithContext freezes tenantId, the rest server refuses the requests of its access keys
+// until it is unfrozen.
+func (admin *AdminClient) FreezeTenantWithContext(ctx context.Context, bizid, tenantId string) error {
+	return admin.setTenantFrozen(ctx, model.FROZENTENANT, bizid, tenantId)
+}
+
+func (admin *AdminClient) UnfreezeTenant(bizid, tenantId string) error {
+	return admin.UnfreezeTenantWithContext(context.Background(), bizid, tenantId)
+}
+
+func (admin *AdminClient) UnfreezeTenantWithContext(ctx context.Context, bizid, tenantId string) error {
+	return admin.setTenantFrozen(ctx, model.UNFROZENTENANT, bizid, tenantId)
+}
+
+func (admin *AdminClient) setTenantFrozen(ctx context.Context, method model.Method, bizid, tenantId string) error {
+	return admin.client.chainCallForBizResult(ctx, model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId: admin.client.RestClientProperties.AccessId,
+			BizId:    bizid,
+			Method:   method,
+		},
+		TenantId: tenantId,
+	}, nil)
+}
+
+func (admin *AdminClient) RegisterBlockchainConfig(bizid, orderId string, config model.BlockchainConfig) error {
+	return admin.RegisterBlockchainConfigWithContext(context.Background(), bizid, orderId, config)
+}
+
+// RegisterBlockchainConfigWithContext registers the nodes and certificates the rest server connects
+// to the chain bizid with.
+func (admin *AdminClient) RegisterBlockchainConfigWithContext(ctx context.Context, bizid, orderId string, config model.BlockchainConfig) error {
+	if len(config.Nodes) == 0 {
+		return response.NewValidationError(fmt.Sprintf("%v method must has nodes", model.REGISTERBLOCKCHAINCONFIG))
+	}
+	requestStr, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return admin.client.chainCallForBizResult(ctx, model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId:   admin.client.RestClientProperties.AccessId,
+			BizId:      bizid,
+			Method:     model.REGISTERBLOCKCHAINCONFIG,
+			RequestStr: string(requestStr),
+		},
+		OrderId: orderId,
+	}, nil)
+}
+
+func (admin *AdminClient) DepositWithAdmin(bizid, orderId, tenantId, content string, gas int64) (response.BaseResp, error) {
+	return admin.DepositWithAdminWithContext(context.Background(), bizid, orderId, tenantId, content, gas)
+}
+
+// DepositWithAdminWithContext deposits content for tenantId with the admin account of the chain, Data
+// holds the transaction hash.
+func (admin *AdminClient) DepositWithAdminWithContext(ctx context.Context, bizid, orderId, tenantId, content string, gas int64) (response.BaseResp, error) {
+	return admin.client.ChainCallForBizWithContext(ctx, model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId: admin.client.RestClientProperties.AccessId,
+			BizId:    bizid,
+			Method:   model.DEPOSITWITHADMIN,
+		},
+		OrderId:  orderId,
+		TenantId: tenantId,
+		Content:  content,
+		Gas:      gas, // 0表示不受限
+	})
+}
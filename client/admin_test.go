@@ -0,0 +1,77 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/client/internal/resttest"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+)
+
+func newTestAdminClient(t *testing.T, opts ...resttest.Option) (*resttest.Server, *RestClient, *AdminClient) {
+	server := newTestServer(t, opts...)
+	configFilePath := writeTestConfig(t, server, RestBizTestAccessID, RestBizTestKeyPath)
+	defer os.Remove(configFilePath)
+	restClient, err := NewRestClient(configFilePath, WithAdmin())
+	require.NoError(t, err)
+	admin, err := restClient.Admin()
+	require.NoError(t, err)
+	return server, restClient, admin
+}
+
+func TestAdminMethodsNeedAdminClient(t *testing.T) {
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	_, err := restClient.Admin()
+	require.Truef(t, errors.Is(err, response.ErrValidation), "expect validation err:%+v", err)
+
+	_, err = restClient.ChainCallForBiz(model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId: restClient.RestClientProperties.AccessId,
+			BizId:    RestBizTestBizID,
+			Method:   model.FROZENTENANT,
+		},
+		TenantId: RestBizTestTenantID,
+	})
+	require.Truef(t, errors.Is(err, response.ErrValidation), "expect validation err:%+v", err)
+	_, err = restClient.ChainCall("", RestBizTestBizID, "", model.DEPOSITWITHADMIN)
+	require.Truef(t, errors.Is(err, response.ErrValidation), "expect validation err:%+v", err)
+	require.Equal(t, 0, server.Calls(model.FROZENTENANT))
+	require.Equal(t, 0, server.Calls(model.DEPOSITWITHADMIN))
+}
+
+func TestAdminClient(t *testing.T) {
+	server, restClient, admin := newTestAdminClient(t)
+	defer server.Close()
+
+	require.NoError(t, admin.FreezeTenant(RestBizTestBizID, RestBizTestTenantID))
+	require.True(t, server.TenantFrozen(RestBizTestTenantID))
+	orderId := fmt.Sprintf("order_%v", uuid.New().String())
+	baseResp, err := restClient.Deposit(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, "content", RestBizTestKmsID, 50000)
+	require.Truef(t, err == nil && !baseResp.Success, "expect frozen tenant to be refused baseResp:%+v err:%+v", baseResp, err)
+	require.NoError(t, admin.UnfreezeTenant(RestBizTestBizID, RestBizTestTenantID))
+	require.False(t, server.TenantFrozen(RestBizTestTenantID))
+
+	err = admin.FreezeTenant(RestBizTestBizID, "")
+	require.Truef(t, errors.Is(err, response.ErrValidation), "expect validation err:%+v", err)
+
+	config := model.BlockchainConfig{Name: "test_chain", Nodes: []string{"127.0.0.1:18130", "127.0.0.1:18131"}}
+	orderId = fmt.Sprintf("order_%v", uuid.New().String())
+	require.NoError(t, admin.RegisterBlockchainConfig(RestBizTestBizID, orderId, config))
+	registered, ok := server.BlockchainConfig(RestBizTestBizID)
+	require.True(t, ok)
+	require.Equal(t, config, registered)
+	err = admin.RegisterBlockchainConfig(RestBizTestBizID, orderId, model.BlockchainConfig{Name: "test_chain"})
+	require.Truef(t, errors.Is(err, response.ErrValidation), "expect validation err:%+v", err)
+
+	orderId = fmt.Sprintf("order_%v", uuid.New().String())
+	baseResp, err = admin.DepositWithAdmin(RestBizTestBizID, orderId, RestBizTestTenantID, "content", 50000)
+	require.Truef(t, err == nil && baseResp.Success && baseResp.Code == "200", "no succ resp baseResp:%+v err:%+v", baseResp, err)
+	tx, ok := server.Transaction(baseResp.Data)
+	require.True(t, ok)
+	require.Equal(t, model.Method(model.DEPOSITWITHADMIN), tx.Param.Method)
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/mychain"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+	"strconv"
+)
+
+func (client *RestClient) QueryBlock(bizid string, blockNumber int64) (mychain.Block, error) {
+	return client.QueryBlockWithContext(context.Background(), bizid, blockNumber)
+}
+
+// QueryBlockWithContext queries the header and body of block blockNumber with QUERYBLOCK.
+func (client *RestClient) QueryBlockWithContext(ctx context.Context, bizid string, blockNumber int64) (mychain.Block, error) {
+	block := mychain.Block{}
+	err := client.queryBlock(ctx, bizid, strconv.FormatInt(blockNumber, 10), model.QUERYBLOCK, &block)
+	return block, err
+}
+
+func (client *RestClient) QueryBlockHeader(bizid string, blockNumber int64) (mychain.BlockHeader, error) {
+	return client.QueryBlockHeaderWithContext(context.Background(), bizid, blockNumber)
+}
+
+// QueryBlockHeaderWithContext queries the header of block blockNumber with QUERYBLOCKHEADERINFOSRAW.
+func (client *RestClient) QueryBlockHeaderWithContext(ctx context.Context, bizid string, blockNumber int64) (mychain.BlockHeader, error) {
+	header := mychain.BlockHeader{}
+	err := client.queryBlock(ctx, bizid, strconv.FormatInt(blockNumber, 10), model.QUERYBLOCKHEADERINFOSRAW, &header)
+	return header, err
+}
+
+func (client *RestClient) QueryBlockBody(bizid string, blockNumber int64) (mychain.BlockBody, error) {
+	return client.QueryBlockBodyWithContext(context.Background(), bizid, blockNumber)
+}
+
+// QueryBlockBodyWithContext queries the transactions and receipts of block blockNumber with QUERYBLOCKBODY.
+func (client *RestClient) QueryBlockBodyWithContext(ctx context.Context, bizid string, blockNumber int64) (mychain.BlockBody, error) {
+	body := mychain.BlockBody{}
+	err := client.queryBlock(ctx, bizid, strconv.FormatInt(blockNumber, 10), model.QUERYBLOCKBODY, &body)
+	return body, err
+}
+
+func (client *RestClient) QueryLastBlock(bizid string) (mychain.Block, error) {
+	return client.QueryLastBlockWithContext(context.Background(), bizid)
+}
+
+// QueryLastBlockWithContext queries the latest block of the chain with QUERYLASTBLOCK.
+func (client *RestClient) QueryLastBlockWithContext(ctx context.Context, bizid string) (mychain.Block, error) {
+	block := mychain.Block{}
+	err := client.queryBlock(ctx, bizid, "", model.QUERYLASTBLOCK, &block)
+	return block, err
+}
+
+// queryBlock sends a block query with the block number as requestStr and parses Data into v.
+func (client *RestClient) queryBlock(ctx context.Context, bizid, requestStr string, method model.Method, v interface{}) error {
+	baseResp, err := client.ChainCallWithContext(ctx, "", bizid, requestStr, method)
+	if err != nil {
+		return err
+	}
+	if !baseResp.Success || baseResp.Code != model.ServiceSuccess {
+		return fmt.Errorf("%v failed,block:%v err:%w", method, requestStr, response.NewCodeError(baseResp.Code, baseResp.Data, 0))
+	}
+	if err := json.Unmarshal([]byte(baseResp.Data), v); err != nil {
+		return fmt.Errorf("%v returned an invalid block,block:%v err:%w", method, requestStr, err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestQueryBlock(t *testing.T) {
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	_, err := restClient.QueryLastBlock(RestBizTestBizID)
+	require.Truef(t, errors.Is(err, response.ErrNotFound), "expect no block err:%+v", err)
+
+	var hashes []string
+	for _, content := range []string{"first", "second"} {
+		orderId := fmt.Sprintf("order_%v", uuid.New().String())
+		baseResp, err := restClient.Deposit(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, content, RestBizTestKmsID, 50000)
+		require.Truef(t, err == nil && baseResp.Success, "no succ resp baseResp:%+v err:%+v", baseResp, err)
+		hashes = append(hashes, baseResp.Data)
+	}
+
+	last, err := restClient.QueryLastBlock(RestBizTestBizID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), last.Header.Number)
+	require.Len(t, last.Body.TransactionList, 1)
+	require.Equal(t, hashes[1], last.Body.TransactionList[0].Hash)
+	require.Len(t, last.Body.ReceiptList, 1)
+
+	block, err := restClient.QueryBlock(RestBizTestBizID, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), block.Header.Number)
+	require.Equal(t, block.Header.Hash, last.Header.ParentHash)
+	require.NotZero(t, block.Header.Timestamp)
+	data, err := base64.StdEncoding.DecodeString(block.Body.TransactionList[0].Data)
+	require.NoError(t, err)
+	require.Equal(t, "first", string(data))
+
+	header, err := restClient.QueryBlockHeader(RestBizTestBizID, 2)
+	require.NoError(t, err)
+	require.Equal(t, last.Header, header)
+	body, err := restClient.QueryBlockBody(RestBizTestBizID, 1)
+	require.NoError(t, err)
+	require.Equal(t, block.Body, body)
+
+	_, err = restClient.QueryBlock(RestBizTestBizID, 3)
+	require.Truef(t, errors.Is(err, response.ErrNotFound), "expect no block err:%+v", err)
+	require.Equal(t, 2, server.Calls(model.QUERYBLOCK))
+}
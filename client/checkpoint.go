@@ -0,0 +1,177 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Checkpoint is the position of an EventListener: the block to read next and how many of its logs, in
+// receipt order and whether they matched or not, were already passed. Counting every log keeps the
+// position valid when the filters of the listener change.
+type Checkpoint struct {
+	BlockNumber int64 `json:"blockNumber"`
+	EventIndex  int   `json:"eventIndex"`
+}
+
+// CheckpointStore persists the checkpoint of each listener key, Load returns false when key has none.
+type CheckpointStore interface {
+	Load(ctx context.Context, key string) (Checkpoint, bool, error)
+	Save(ctx context.Context, key string, checkpoint Checkpoint) error
+}
+
+// MemoryCheckpointStore keeps checkpoints for the life of the process.
+type MemoryCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[string]Checkpoint
+}
+
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{checkpoints: make(map[string]Checkpoint)}
+}
+
+func (store *MemoryCheckpointStore) Load(ctx context.Context, key string) (Checkpoint, bool, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	checkpoint, ok := store.checkpoints[key]
+	return checkpoint, ok, nil
+}
+
+func (store *MemoryCheckpointStore) Save(ctx context.Context, key string, checkpoint Checkpoint) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.checkpoints[key] = checkpoint
+	return nil
+}
+
+// FileCheckpointStore keeps the checkpoints of every key as json in one file, which is replaced
+// atomically on each save.
+type FileCheckpointStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+func (store *FileCheckpointStore) Load(ctx context.Context, key string) (Checkpoint, bool, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	checkpoints, err := store.read()
+	if err != nil {
+		return Checkpoint{}, false, err
+	}
+	checkpoint, ok := checkpoints[key]
+	return checkpoint, ok, nil
+}
+
+func (store *FileCheckpointStore) Save(ctx context.Context, key string, checkpoint Checkpoint) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	checkpoints, err := store.read()
+	if err != nil {
+		return err
+	}
+	checkpoints[key] = checkpoint
+	bytes, err := json.Marshal(checkpoints)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(store.path), filepath.Base(store.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(bytes); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), store.path)
+}
+
+func (store *FileCheckpointStore) read() (map[string]Checkpoint, error) {
+	checkpoints := make(map[string]Checkpoint)
+	bytes, err := ioutil.ReadFile(store.path)
+	if os.IsNotExist(err) {
+		return checkpoints, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bytes, &checkpoints); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint file %v,err:%w", store.path, err)
+	}
+	return checkpoints, nil
+}
+
+// RemoteCheckpointStore keeps the block number of each key, used as the event topic, on the BaaS with
+// GETEVENTTOPICBLOCKNUM and UPDATEEVENTTOPICBLOCKNUM. Only block boundaries are saved, so a listener
+// restarted in the middle of a block delivers the events of that block again.
+type RemoteCheckpointStore struct {
+	client *RestClient
+	bizid  string
+}
+
+func NewRemoteCheckpointStore(client *RestClient, bizid string) *RemoteCheckpointStore {
+	return &RemoteCheckpointStore{client: client, bizid: bizid}
+}
+
+func (store *RemoteCheckpointStore) Load(ctx context.Context, key string) (Checkpoint, bool, error) {
+	baseResp, err := store.client.ChainCallForBizWithContext(ctx, store.param(model.GETEVENTTOPICBLOCKNUM, key, 0))
+	if err != nil {
+		return Checkpoint{}, false, err
+	}
+	if baseResp.Code == model.ServiceQueryNoResult {
+		return Checkpoint{}, false, nil
+	}
+	if !baseResp.Success || baseResp.Code != model.ServiceSuccess {
+		return Checkpoint{}, false, fmt.Errorf("get event topic block number failed,topic:%v err:%w", key, response.NewCodeError(baseResp.Code, baseResp.Data, 0))
+	}
+	blockNumber, err := strconv.ParseInt(strings.TrimSpace(baseResp.Data), 10, 64)
+	if err != nil {
+		return Checkpoint{}, false, fmt.Errorf("invalid event topic block number,topic:%v data:%v", key, baseResp.Data)
+	}
+	return Checkpoint{BlockNumber: blockNumber}, true, nil
+}
+
+func (store *RemoteCheckpointStore) Save(ctx context.Context, key string, checkpoint Checkpoint) error {
+	if checkpoint.EventIndex != 0 {
+		return nil
+	}
+	baseResp, err := store.client.ChainCallForBizWithContext(ctx, store.param(model.UPDATEEVENTTOPICBLOCKNUM, key, checkpoint.BlockNumber))
+	if err != nil {
+		return err
+	}
+	if !baseResp.Success || baseResp.Code != model.ServiceSuccess {
+		return fmt.Errorf("update event topic block number failed,topic:%v err:%w", key, response.NewCodeError(baseResp.Code, baseResp.Data, 0))
+	}
+	return nil
+}
+
+func (store *RemoteCheckpointStore) param(method model.Method, topic string, blockNumber int64) model.CallRestBizParam {
+	return model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId: store.client.RestClientProperties.AccessId,
+			BizId:    store.bizid,
+			Method:   method,
+		},
+		Content:     topic,
+		BlockNumber: blockNumber,
+	}
+}
@@ -1,13 +1,67 @@
 package config
 
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// DefaultEnvPrefix is the prefix of the environment variables read by LoadFromEnv.
+const DefaultEnvPrefix = "ANTCHAIN_REST_"
+
 type RestClientProperties struct {
-	RestUrl           string `json:"RestUrl"`
-	AccessId          string `json:"AccessId""`
-	AccessSecret      string `json:"AccessSecret"`
+	RestUrl      string `json:"RestUrl"`
+	AccessId     string `json:"AccessId"`
+	AccessSecret string `json:"AccessSecret"`
 
 	MaxIdleConns    int `json:"MaxIdleConns"`
 	IdleConnTimeout int `json:"IdleConnTimeout"` // 单位为秒
 
 	RetryMaxAttempts int `json:"RetryMaxAttempts"` // http.client 重试次数
-	BackOffPeriod    int `json:"BackOffPeriod"`    // http.client重试间隔,单位为毫秒
+	BackOffPeriod    int `json:"BackOffPeriod"`    // http.client首次重试间隔,之后指数增长,单位为毫秒
+
+	TokenTTL int `json:"TokenTTL"` // rest token有效期,单位为秒,0表示只在token过期后才重新握手
+}
+
+// Load decodes json encoded properties from r.
+func Load(r io.Reader) (RestClientProperties, error) {
+	restClientProperties := RestClientProperties{}
+	if err := json.NewDecoder(r).Decode(&restClientProperties); err != nil {
+		return RestClientProperties{}, fmt.Errorf("fail to parse restClientProperties,err:%w", err)
+	}
+	return restClientProperties, nil
+}
+
+// LoadFromEnv reads properties from environment variables named prefix + URL, ACCESS_ID,
+// ACCESS_SECRET, MAX_IDLE_CONNS, IDLE_CONN_TIMEOUT, RETRY_MAX_ATTEMPTS, BACK_OFF_PERIOD and TOKEN_TTL.
+func LoadFromEnv(prefix string) (RestClientProperties, error) {
+	restClientProperties := RestClientProperties{
+		RestUrl:      os.Getenv(prefix + "URL"),
+		AccessId:     os.Getenv(prefix + "ACCESS_ID"),
+		AccessSecret: os.Getenv(prefix + "ACCESS_SECRET"),
+	}
+	if restClientProperties.RestUrl == "" || restClientProperties.AccessId == "" || restClientProperties.AccessSecret == "" {
+		return RestClientProperties{}, fmt.Errorf("%vURL, %vACCESS_ID and %vACCESS_SECRET must be set", prefix, prefix, prefix)
+	}
+	ints := map[string]*int{
+		"MAX_IDLE_CONNS":     &restClientProperties.MaxIdleConns,
+		"IDLE_CONN_TIMEOUT":  &restClientProperties.IdleConnTimeout,
+		"RETRY_MAX_ATTEMPTS": &restClientProperties.RetryMaxAttempts,
+		"BACK_OFF_PERIOD":    &restClientProperties.BackOffPeriod,
+		"TOKEN_TTL":          &restClientProperties.TokenTTL,
+	}
+	for name, field := range ints {
+		value, ok := os.LookupEnv(prefix + name)
+		if !ok || value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return RestClientProperties{}, fmt.Errorf("%v%v is not an integer,value:%v", prefix, name, value)
+		}
+		*field = n
+	}
+	return restClientProperties, nil
 }
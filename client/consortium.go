@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/mychain"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+func (admin *AdminClient) InviteUser(bizid, orderId string, request model.InviteUserRequest) (model.Invitation, error) {
+	return admin.InviteUserWithContext(context.Background(), bizid, orderId, request)
+}
+
+// InviteUserWithContext invites request.TenantId into the consortium of the chain bizid.
+func (admin *AdminClient) InviteUserWithContext(ctx context.Context, bizid, orderId string, request model.InviteUserRequest) (model.Invitation, error) {
+	if request.TenantId == "" {
+		return model.Invitation{}, response.NewValidationError(fmt.Sprintf("%v method must has tenantId", model.INVITEUSER))
+	}
+	invitation := model.Invitation{}
+	err := admin.consortiumCall(ctx, model.INVITEUSER, bizid, orderId, request, &invitation)
+	return invitation, err
+}
+
+func (admin *AdminClient) NewChain(bizid, orderId string, request model.NewChainRequest) (model.Chain, error) {
+	return admin.NewChainWithContext(context.Background(), bizid, orderId, request)
+}
+
+// NewChainWithContext provisions a chain in the consortium of the chain bizid and returns it as soon
+// as it is accepted, use WaitForChainReady before sending requests to the returned BizId.
+func (admin *AdminClient) NewChainWithContext(ctx context.Context, bizid, orderId string, request model.NewChainRequest) (model.Chain, error) {
+	if request.Name == "" {
+		return model.Chain{}, response.NewValidationError(fmt.Sprintf("%v method must has name", model.NEWCHAIN))
+	}
+	chain := model.Chain{}
+	err := admin.consortiumCall(ctx, model.NEWCHAIN, bizid, orderId, request, &chain)
+	return chain, err
+}
+
+func (admin *AdminClient) consortiumCall(ctx context.Context, method model.Method, bizid, orderId string, request, v interface{}) error {
+	requestStr, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	return admin.client.chainCallForBizResult(ctx, model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId:   admin.client.RestClientProperties.AccessId,
+			BizId:      bizid,
+			Method:     method,
+			RequestStr: string(requestStr),
+		},
+		OrderId: orderId,
+	}, v)
+}
+
+func (client *RestClient) WaitForChainReady(bizid string, opts WaitOptions) (mychain.Block, error) {
+	return client.WaitForChainReadyWithContext(context.Background(), bizid, opts)
+}
+
+// WaitForChainReadyWithContext polls QUERYLASTBLOCK on the chain bizid until it answers with a block,
+// which is returned. Polls the chain is not ready for, see chainNotReady, are retried with the backoff
+// of opts, other errors end the wait. ErrWaitTimeout is returned when opts.Timeout or opts.MaxPolls is
+// reached first.
+func (client *RestClient) WaitForChainReadyWithContext(ctx context.Context, bizid string, opts WaitOptions) (mychain.Block, error) {
+	opts = client.waitOptions(opts)
+	waitCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	interval := opts.PollInterval
+	for polls := 1; ; polls++ {
+		block, err := client.QueryLastBlockWithContext(waitCtx, bizid)
+		if err == nil {
+			return block, nil
+		}
+		if ctx.Err() == nil && waitCtx.Err() == context.DeadlineExceeded {
+			return mychain.Block{}, fmt.Errorf("%w,chain:%v polls:%v", ErrWaitTimeout, bizid, polls)
+		}
+		if !chainNotReady(err) {
+			return mychain.Block{}, err
+		}
+		if opts.MaxPolls > 0 && polls >= opts.MaxPolls {
+			return mychain.Block{}, fmt.Errorf("%w,chain:%v polls:%v", ErrWaitTimeout, bizid, polls)
+		}
+
+		client.logger.WithFields(log.Fields{
+			"bizid":    bizid,
+			"polls":    polls,
+			"interval": interval.String(),
+		}).Info("wait for chain ready")
+		if err := sleepWithContext(waitCtx, interval); err != nil {
+			if ctx.Err() == nil {
+				return mychain.Block{}, fmt.Errorf("%w,chain:%v polls:%v", ErrWaitTimeout, bizid, polls)
+			}
+			return mychain.Block{}, err
+		}
+		interval = time.Duration(float64(interval) * opts.Multiplier)
+		if interval > opts.MaxPollInterval {
+			interval = opts.MaxPollInterval
+		}
+	}
+}
+
+// chainNotReady reports whether err is the answer of a chain created by NEWCHAIN whose genesis block is
+// not committed yet: QUERYLASTBLOCK then finds no block and answers model.ServiceQueryNoResult. Other
+// results are not waited on: a validation error does not go away by polling, and server errors were
+// already retried by the retry policy of the client.
+func chainNotReady(err error) bool {
+	return errors.Is(err, response.ErrNotFound)
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/client/internal/resttest"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestInviteUser(t *testing.T) {
+	server, _, admin := newTestAdminClient(t)
+	defer server.Close()
+	orderId := fmt.Sprintf("order_%v", uuid.New().String())
+	invitation, err := admin.InviteUser(RestBizTestBizID, orderId, model.InviteUserRequest{TenantId: "invited_tenant", Role: "MEMBER"})
+	require.NoError(t, err)
+	require.NotEmpty(t, invitation.InviteId)
+	require.Equal(t, RestBizTestBizID, invitation.BizId)
+	require.Equal(t, "invited_tenant", invitation.TenantId)
+	require.Equal(t, "INVITED", invitation.Status)
+
+	_, err = admin.InviteUser(RestBizTestBizID, orderId, model.InviteUserRequest{})
+	require.Truef(t, errors.Is(err, response.ErrValidation), "expect validation err:%+v", err)
+}
+
+func TestNewChainAndWaitForChainReady(t *testing.T) {
+	server, restClient, admin := newTestAdminClient(t, resttest.WithNewChainPolls(2))
+	defer server.Close()
+	orderId := fmt.Sprintf("order_%v", uuid.New().String())
+	chain, err := admin.NewChain(RestBizTestBizID, orderId, model.NewChainRequest{Name: "test_chain", NodeCount: 4})
+	require.NoError(t, err)
+	require.NotEmpty(t, chain.BizId)
+	require.Equal(t, "test_chain", chain.Name)
+
+	block, err := restClient.WaitForChainReady(chain.BizId, WaitOptions{PollInterval: time.Millisecond})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), block.Header.Number)
+	require.Equal(t, 3, server.Calls(model.QUERYLASTBLOCK))
+
+	_, err = admin.NewChain(RestBizTestBizID, orderId, model.NewChainRequest{})
+	require.Truef(t, errors.Is(err, response.ErrValidation), "expect validation err:%+v", err)
+}
+
+func TestWaitForChainReadyTimeout(t *testing.T) {
+	server, restClient, admin := newTestAdminClient(t, resttest.WithNewChainPolls(10))
+	defer server.Close()
+	orderId := fmt.Sprintf("order_%v", uuid.New().String())
+	chain, err := admin.NewChain(RestBizTestBizID, orderId, model.NewChainRequest{Name: "test_chain"})
+	require.NoError(t, err)
+
+	_, err = restClient.WaitForChainReady(chain.BizId, WaitOptions{PollInterval: time.Millisecond, MaxPolls: 3})
+	require.Truef(t, errors.Is(err, ErrWaitTimeout), "expect wait timeout err:%+v", err)
+	require.Equal(t, 3, server.Calls(model.QUERYLASTBLOCK))
+
+	// only a missing block means the chain is not ready yet
+	server.InjectFault(resttest.Fault{Method: model.QUERYLASTBLOCK, Code: "400", Data: "biz not exist"})
+	_, err = restClient.WaitForChainReady(chain.BizId, WaitOptions{PollInterval: time.Millisecond, MaxPolls: 3})
+	require.Truef(t, errors.Is(err, response.ErrUnsuccessfulResult), "expect unsuccessful result err:%+v", err)
+	require.Equal(t, 4, server.Calls(model.QUERYLASTBLOCK))
+}
@@ -0,0 +1,197 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"github.com/ctwel/antchain-client-go-sdk/mychain"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+	log "github.com/sirupsen/logrus"
+	"strings"
+	"time"
+)
+
+var DefaultEventPollInterval = 2 * time.Second
+
+// EventFilter matches the logs of the contract ContractName carrying any of Topics, empty fields match
+// every contract and every topic.
+type EventFilter struct {
+	ContractName string
+	Topics       []string
+}
+
+// ListenerOptions configures an EventListener. Key names its checkpoint in Store, a MemoryCheckpointStore
+// when nil. Without a checkpoint the listener starts at StartBlock, 0 being the genesis block, or at the
+// last block of the chain when FromLatest is set. A log is delivered when it matches any of Filters, or
+// always when there are none.
+type ListenerOptions struct {
+	Key          string
+	Store        CheckpointStore
+	StartBlock   int64
+	FromLatest   bool
+	PollInterval time.Duration
+	Filters      []EventFilter
+}
+
+// Event is a contract log found by an EventListener. TxIndex is the index of the transaction in its
+// block and LogIndex the index of the log in the receipt.
+type Event struct {
+	BlockNumber int64
+	TxHash      string
+	TxIndex     int
+	LogIndex    int
+	Log         mychain.Log
+}
+
+// EventListener walks the blocks of a chain and delivers the contract logs matching its filters in
+// order, saving its checkpoint after every event and every block.
+type EventListener struct {
+	client *RestClient
+	bizid  string
+	opts   ListenerOptions
+}
+
+func (client *RestClient) NewEventListener(bizid string, opts ListenerOptions) (*EventListener, error) {
+	if opts.Key == "" {
+		return nil, response.NewValidationError("event listener must has key")
+	}
+	if opts.Store == nil {
+		opts.Store = NewMemoryCheckpointStore()
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultEventPollInterval
+	}
+	return &EventListener{client: client, bizid: bizid, opts: opts}, nil
+}
+
+// Run calls handler with every matching event until ctx is done or handler returns an error, the
+// checkpoint is only advanced past events handler returned nil for. Run returns the handler error,
+// ctx.Err() or the first failed query or checkpoint save.
+func (listener *EventListener) Run(ctx context.Context, handler func(Event) error) error {
+	checkpoint, ok, err := listener.opts.Store.Load(ctx, listener.opts.Key)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		checkpoint = Checkpoint{BlockNumber: listener.opts.StartBlock}
+	}
+	fromLatest := !ok && listener.opts.FromLatest
+	for {
+		last, err := listener.client.QueryLastBlockWithContext(ctx, listener.bizid)
+		if err != nil && !errors.Is(err, response.ErrNotFound) {
+			return err
+		}
+		if fromLatest && err == nil {
+			checkpoint.BlockNumber = last.Header.Number
+			fromLatest = false
+		}
+		for err == nil && checkpoint.BlockNumber <= last.Header.Number {
+			if checkpoint, err = listener.deliver(ctx, checkpoint, handler); err != nil {
+				return err
+			}
+		}
+		if err := sleepWithContext(ctx, listener.opts.PollInterval); err != nil {
+			return err
+		}
+	}
+}
+
+// Subscribe runs the listener in a goroutine delivering events on the returned channel, an event counts
+// as delivered once it is received. The error Run stopped with is sent on the error channel, then both
+// channels are closed.
+func (listener *EventListener) Subscribe(ctx context.Context) (<-chan Event, <-chan error) {
+	events := make(chan Event)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(events)
+		defer close(errc)
+		errc <- listener.Run(ctx, func(event Event) error {
+			select {
+			case events <- event:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+	return events, errc
+}
+
+// deliver hands the events of block checkpoint.BlockNumber to handler, skipping the logs of the block
+// before checkpoint.EventIndex, and returns the checkpoint of the next block.
+func (listener *EventListener) deliver(ctx context.Context, checkpoint Checkpoint, handler func(Event) error) (Checkpoint, error) {
+	block, err := listener.client.QueryBlockWithContext(ctx, listener.bizid, checkpoint.BlockNumber)
+	if err != nil {
+		return checkpoint, err
+	}
+	delivered := 0
+	blockLogIndex := 0
+	for txIndex, receipt := range block.Body.ReceiptList {
+		txHash := ""
+		if txIndex < len(block.Body.TransactionList) {
+			txHash = block.Body.TransactionList[txIndex].Hash
+		}
+		for logIndex, contractLog := range receipt.Logs {
+			blockLogIndex++
+			if blockLogIndex <= checkpoint.EventIndex || !listener.matches(contractLog) {
+				continue
+			}
+			event := Event{
+				BlockNumber: checkpoint.BlockNumber,
+				TxHash:      txHash,
+				TxIndex:     txIndex,
+				LogIndex:    logIndex,
+				Log:         contractLog,
+			}
+			if err := handler(event); err != nil {
+				return checkpoint, err
+			}
+			delivered++
+			checkpoint.EventIndex = blockLogIndex
+			if err := listener.opts.Store.Save(ctx, listener.opts.Key, checkpoint); err != nil {
+				return checkpoint, err
+			}
+		}
+	}
+	next := Checkpoint{BlockNumber: checkpoint.BlockNumber + 1}
+	if err := listener.opts.Store.Save(ctx, listener.opts.Key, next); err != nil {
+		return checkpoint, err
+	}
+	listener.client.logger.WithFields(log.Fields{
+		"key":    listener.opts.Key,
+		"block":  checkpoint.BlockNumber,
+		"events": delivered,
+	}).Debug("event listener passed block")
+	return next, nil
+}
+
+func (listener *EventListener) matches(contractLog mychain.Log) bool {
+	if len(listener.opts.Filters) == 0 {
+		return true
+	}
+	for _, filter := range listener.opts.Filters {
+		if filter.matches(contractLog) {
+			return true
+		}
+	}
+	return false
+}
+
+func (filter EventFilter) matches(contractLog mychain.Log) bool {
+	if filter.ContractName != "" {
+		to := strings.TrimPrefix(strings.ToLower(contractLog.To), "0x")
+		if to != mychain.NewIdentity(filter.ContractName).Hex() {
+			return false
+		}
+	}
+	if len(filter.Topics) == 0 {
+		return true
+	}
+	for _, topic := range filter.Topics {
+		for _, logTopic := range contractLog.Topics {
+			if topic == logTopic {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/client/internal/resttest"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/mychain"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// commitLogs commits one transaction per entry of logs, nil entries commit a transaction without logs.
+func commitLogs(server *resttest.Server, logs ...[]mychain.Log) []string {
+	var hashes []string
+	for i, txLogs := range logs {
+		param := model.CallRestBizParam{OrderId: fmt.Sprintf("order_%v", i), Account: RestBizTestAccount}
+		hashes = append(hashes, server.CommitWithLogs(param, nil, txLogs))
+	}
+	return hashes
+}
+
+func contractLog(contractName string, topics ...string) mychain.Log {
+	return mychain.Log{To: mychain.NewIdentity(contractName).Hex(), Topics: topics}
+}
+
+func TestEventListener_Run(t *testing.T) {
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	hashes := commitLogs(server,
+		[]mychain.Log{contractLog("token", "Transfer"), contractLog("other", "Transfer")},
+		nil,
+		[]mychain.Log{contractLog("token", "Approval"), contractLog("token", "Transfer"), contractLog("token", "Burn")},
+	)
+	dir, err := ioutil.TempDir("", "checkpoint")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	store := NewFileCheckpointStore(filepath.Join(dir, "checkpoint.json"))
+	opts := ListenerOptions{
+		Key:          "token",
+		Store:        store,
+		PollInterval: 10 * time.Millisecond,
+		Filters:      []EventFilter{{ContractName: "token", Topics: []string{"Transfer", "Burn"}}},
+	}
+	listener, err := restClient.NewEventListener(RestBizTestBizID, opts)
+	require.NoError(t, err)
+
+	var events []Event
+	errStop := errors.New("stop")
+	err = listener.Run(context.Background(), func(event Event) error {
+		if len(events) == 2 {
+			return errStop
+		}
+		events = append(events, event)
+		return nil
+	})
+	require.True(t, errors.Is(err, errStop))
+	require.Len(t, events, 2)
+	require.Equal(t, Event{BlockNumber: 1, TxHash: hashes[0], Log: contractLog("token", "Transfer")}, events[0])
+	require.Equal(t, Event{BlockNumber: 3, TxHash: hashes[2], LogIndex: 1, Log: contractLog("token", "Transfer")}, events[1])
+	checkpoint, ok, err := store.Load(context.Background(), "token")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, Checkpoint{BlockNumber: 3, EventIndex: 2}, checkpoint)
+
+	// a new listener resumes after the last handled event, even when its filters changed
+	opts.Filters = []EventFilter{{ContractName: "token"}}
+	listener, err = restClient.NewEventListener(RestBizTestBizID, opts)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err = listener.Run(ctx, func(event Event) error {
+		events = append(events, event)
+		cancel()
+		return nil
+	})
+	require.True(t, errors.Is(err, context.Canceled))
+	require.Len(t, events, 3)
+	require.Equal(t, Event{BlockNumber: 3, TxHash: hashes[2], LogIndex: 2, Log: contractLog("token", "Burn")}, events[2])
+	checkpoint, _, err = store.Load(context.Background(), "token")
+	require.NoError(t, err)
+	require.Equal(t, Checkpoint{BlockNumber: 4}, checkpoint)
+
+	_, err = restClient.NewEventListener(RestBizTestBizID, ListenerOptions{})
+	require.True(t, errors.Is(err, response.ErrValidation))
+}
+
+func TestEventListener_Subscribe(t *testing.T) {
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	commitLogs(server, []mychain.Log{contractLog("token", "Transfer")})
+	store := NewRemoteCheckpointStore(restClient, RestBizTestBizID)
+	_, ok, err := store.Load(context.Background(), "subscriber")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	listener, err := restClient.NewEventListener(RestBizTestBizID, ListenerOptions{Key: "subscriber", Store: store, FromLatest: true, PollInterval: 10 * time.Millisecond})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, errc := listener.Subscribe(ctx)
+	require.Equal(t, int64(1), (<-events).BlockNumber)
+
+	hashes := commitLogs(server, nil, []mychain.Log{contractLog("other", "Mint")})
+	event := <-events
+	require.Equal(t, int64(3), event.BlockNumber)
+	require.Equal(t, hashes[1], event.TxHash)
+	cancel()
+	for range events {
+	}
+	require.True(t, errors.Is(<-errc, context.Canceled))
+
+	checkpoint, ok, err := store.Load(context.Background(), "subscriber")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.True(t, checkpoint.BlockNumber >= 3, "checkpoint:%+v", checkpoint)
+	require.NotZero(t, server.Calls(model.UPDATEEVENTTOPICBLOCKNUM))
+}
+
+func TestMemoryCheckpointStore(t *testing.T) {
+	store := NewMemoryCheckpointStore()
+	_, ok, err := store.Load(context.Background(), "key")
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.NoError(t, store.Save(context.Background(), "key", Checkpoint{BlockNumber: 7, EventIndex: 2}))
+	checkpoint, ok, err := store.Load(context.Background(), "key")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, Checkpoint{BlockNumber: 7, EventIndex: 2}, checkpoint)
+}
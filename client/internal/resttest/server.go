@@ -0,0 +1,891 @@
+// Package resttest provides an in-process stand-in for the BaaS rest server, for the client tests
+// that should not depend on a live network. Its responses mirror what the client expects rather than
+// captured BaaS traffic, so it is internal and not a reference for the wire format.
+package resttest
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/abi"
+	"github.com/ctwel/antchain-client-go-sdk/client/config"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/mychain"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+	"github.com/ctwel/antchain-client-go-sdk/utils"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	shakeHandPath       = "/api/contract/shakeHand"
+	chainCallPath       = "/api/contract/chainCall"
+	chainCallForBizPath = "/api/contract/chainCallForBiz"
+)
+
+// DefaultAccessId is the access id accepted by a server without WithAccessKey.
+const DefaultAccessId = "resttest"
+
+// HandlerFunc answers a chain call, param holds the decoded chainCall or chainCallForBiz body.
+type HandlerFunc func(param model.CallRestBizParam) response.BaseResp
+
+// ContractFunc executes a contract method, outRes is returned to synchronous callers and
+// output is stored base64 encoded in the receipt.
+type ContractFunc func(param model.CallRestBizParam) (outRes []interface{}, output []byte, err error)
+
+// Revert is returned by a ContractFunc to commit the transaction with a failed receipt, the output
+// returned alongside is kept in the receipt.
+type Revert struct {
+	Result int64
+}
+
+func (revert *Revert) Error() string {
+	return fmt.Sprintf("contract reverted,result:%v", revert.Result)
+}
+
+// Fault scripts a failure for the next Times calls of Method, an empty Method matches every chain call.
+// A non zero StatusCode is written as the http status, otherwise Code and Data are returned as an
+// unsuccessful BaseResp.
+type Fault struct {
+	Method     model.Method
+	StatusCode int
+	Code       string
+	Data       string
+	Times      int
+}
+
+// Tx is a transaction committed to the in-memory ledger.
+type Tx struct {
+	Hash        string
+	BlockNumber int64
+	Param       model.CallRestBizParam
+	Result      int64
+	Output      []byte
+	Logs        []mychain.Log
+	Timestamp   int64
+
+	receiptPolls     int
+	transactionPolls int
+}
+
+// Account is an account created through CREATEACCOUNT or CreateAccount.
+type Account struct {
+	Name       string
+	MykmsKeyId string
+	TenantId   string
+	Status     int
+}
+
+// Server is a BaaS rest stand-in backed by an in-memory ledger.
+type Server struct {
+	*httptest.Server
+
+	accessId   string
+	publicKey  *rsa.PublicKey
+	privateKey *rsa.PrivateKey
+
+	tokenTTL       time.Duration
+	pendingVerify  int
+	pendingExecute int
+	chainPolls     int
+
+	mu         sync.Mutex
+	tokens     map[string]time.Time
+	shakeHands int
+	calls      map[model.Method]int
+	faults     []*Fault
+	handlers   map[model.Method]HandlerFunc
+	contracts  map[string]ContractFunc
+	accounts   map[string]*Account
+	txs        map[string]*Tx
+	txList     []*Tx
+	topics     map[string]int64
+	tapps      map[string][]model.TappInfo
+	resources  map[string]model.ResourceMap
+	accessKeys map[string]*model.AccessKey
+	frozen     map[string]bool
+	chains     map[string]model.BlockchainConfig
+	newChains  map[string]int
+}
+
+// Option configures a Server created by NewServer.
+type Option func(server *Server)
+
+// WithAccessKey makes the server accept accessId with signatures made by publicKey's private key.
+func WithAccessKey(accessId string, publicKey *rsa.PublicKey) Option {
+	return func(server *Server) {
+		server.accessId = accessId
+		server.publicKey = publicKey
+	}
+}
+
+// WithTokenTTL expires tokens ttl after their shake hand, chain calls then answer 202.
+func WithTokenTTL(ttl time.Duration) Option {
+	return func(server *Server) {
+		server.tokenTTL = ttl
+	}
+}
+
+// WithPendingStates makes every receipt and transaction query answer 413 verify times and then
+// 414 execute times before the transaction becomes queryable.
+func WithPendingStates(verify, execute int) Option {
+	return func(server *Server) {
+		server.pendingVerify = verify
+		server.pendingExecute = execute
+	}
+}
+
+// WithNewChainPolls makes chains created through NEWCHAIN answer QUERYLASTBLOCK with 404 polls times
+// before they serve their genesis block.
+func WithNewChainPolls(polls int) Option {
+	return func(server *Server) {
+		server.chainPolls = polls
+	}
+}
+
+// NewServer starts a stand-in server, callers must Close it.
+func NewServer(opts ...Option) *Server {
+	server := &Server{
+		accessId:   DefaultAccessId,
+		tokens:     make(map[string]time.Time),
+		calls:      make(map[model.Method]int),
+		handlers:   make(map[model.Method]HandlerFunc),
+		contracts:  make(map[string]ContractFunc),
+		accounts:   make(map[string]*Account),
+		txs:        make(map[string]*Tx),
+		topics:     make(map[string]int64),
+		tapps:      make(map[string][]model.TappInfo),
+		resources:  make(map[string]model.ResourceMap),
+		accessKeys: make(map[string]*model.AccessKey),
+		frozen:     make(map[string]bool),
+		chains:     make(map[string]model.BlockchainConfig),
+		newChains:  make(map[string]int),
+	}
+	for _, opt := range opts {
+		opt(server)
+	}
+	if server.publicKey == nil {
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			panic(fmt.Sprintf("resttest: fail to generate access key,err:%+v", err))
+		}
+		server.privateKey = privateKey
+		server.publicKey = &privateKey.PublicKey
+	}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+	return server
+}
+
+// Properties returns client properties pointing at the server. AccessSecret is left empty,
+// pair them with Signer unless the server was built WithAccessKey.
+func (server *Server) Properties() config.RestClientProperties {
+	return config.RestClientProperties{
+		RestUrl:          server.URL,
+		AccessId:         server.accessId,
+		RetryMaxAttempts: 5,
+		BackOffPeriod:    10,
+	}
+}
+
+// Signer signs with the key generated by NewServer, it is nil for servers built WithAccessKey.
+func (server *Server) Signer() utils.Signer {
+	if server.privateKey == nil {
+		return nil
+	}
+	return utils.NewRSASigner(server.privateKey)
+}
+
+// ExpireTokens invalidates every token handed out so far.
+func (server *Server) ExpireTokens() {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.tokens = make(map[string]time.Time)
+}
+
+// InjectFault queues fault behind the faults already queued, Times defaults to 1.
+func (server *Server) InjectFault(fault Fault) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if fault.Times <= 0 {
+		fault.Times = 1
+	}
+	server.faults = append(server.faults, &fault)
+}
+
+// Handle overrides the built-in handling of method.
+func (server *Server) Handle(method model.Method, handler HandlerFunc) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.handlers[method] = handler
+}
+
+// HandleContract registers the implementation of contractName used by contract calls, and by tapp
+// executions when contractName is a tapp id.
+func (server *Server) HandleContract(contractName string, contract ContractFunc) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.contracts[contractName] = contract
+}
+
+// CreateAccount adds an account to the ledger.
+func (server *Server) CreateAccount(name, mykmsKeyId string) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.accounts[name] = &Account{Name: name, MykmsKeyId: mykmsKeyId}
+}
+
+// Account returns a copy of the named account.
+func (server *Server) Account(name string) (Account, bool) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	account, ok := server.accounts[name]
+	if !ok {
+		return Account{}, false
+	}
+	return *account, true
+}
+
+// TenantFrozen reports whether tenantId was frozen through FROZENTENANT.
+func (server *Server) TenantFrozen(tenantId string) bool {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	return server.frozen[tenantId]
+}
+
+// BlockchainConfig returns the config registered for bizid through REGISTERBLOCKCHAINCONFIG.
+func (server *Server) BlockchainConfig(bizid string) (model.BlockchainConfig, bool) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	config, ok := server.chains[bizid]
+	return config, ok
+}
+
+// Commit appends a transaction to the ledger and returns its hash, for use by custom handlers.
+func (server *Server) Commit(param model.CallRestBizParam, output []byte) string {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	return server.commit(param, output).Hash
+}
+
+// CommitWithLogs commits a transaction whose receipt carries logs and returns its hash.
+func (server *Server) CommitWithLogs(param model.CallRestBizParam, output []byte, logs []mychain.Log) string {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	tx := server.commit(param, output)
+	tx.Logs = logs
+	return tx.Hash
+}
+
+// Transaction returns a copy of the committed transaction.
+func (server *Server) Transaction(hash string) (Tx, bool) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	tx, ok := server.txs[hash]
+	if !ok {
+		return Tx{}, false
+	}
+	return *tx, true
+}
+
+// ShakeHands returns the number of successful shake hands.
+func (server *Server) ShakeHands() int {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	return server.shakeHands
+}
+
+// Calls returns the number of chain calls received for method, including rejected ones.
+func (server *Server) Calls(method model.Method) int {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	return server.calls[method]
+}
+
+func (server *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	switch r.URL.Path {
+	case shakeHandPath:
+		server.serveShakeHand(w, r)
+	case chainCallPath, chainCallForBizPath:
+		server.serveChainCall(w, r)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (server *Server) serveShakeHand(w http.ResponseWriter, r *http.Request) {
+	shakeRequest := model.ShakeRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&shakeRequest); err != nil {
+		writeResp(w, response.BaseResp{Code: "400", Data: err.Error()})
+		return
+	}
+	if shakeRequest.AccessId != server.accessId {
+		writeResp(w, response.BaseResp{Code: "401", Data: "unknown access id"})
+		return
+	}
+	sig, err := hex.DecodeString(shakeRequest.Secret)
+	digest := sha256.Sum256([]byte(shakeRequest.AccessId + shakeRequest.Time))
+	if err != nil || rsa.VerifyPKCS1v15(server.publicKey, crypto.SHA256, digest[:], sig) != nil {
+		writeResp(w, response.BaseResp{Code: "401", Data: "invalid secret"})
+		return
+	}
+
+	token := randomHex(16)
+	server.mu.Lock()
+	server.tokens[token] = time.Now()
+	server.shakeHands++
+	server.mu.Unlock()
+	writeResp(w, response.BaseResp{Success: true, Code: model.ServiceSuccess, Data: token})
+}
+
+func (server *Server) serveChainCall(w http.ResponseWriter, r *http.Request) {
+	param := model.CallRestBizParam{}
+	if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
+		writeResp(w, response.BaseResp{Code: "400", Data: err.Error()})
+		return
+	}
+
+	server.mu.Lock()
+	server.calls[param.Method]++
+	if fault := server.nextFault(param.Method); fault != nil {
+		server.mu.Unlock()
+		if fault.StatusCode != 0 {
+			w.WriteHeader(fault.StatusCode)
+			fmt.Fprint(w, fault.Data)
+			return
+		}
+		writeResp(w, response.BaseResp{Code: fault.Code, Data: fault.Data})
+		return
+	}
+	if !server.validToken(param.Token) {
+		server.mu.Unlock()
+		writeResp(w, response.BaseResp{Code: model.ServiceTokenExpired, Data: "token expired"})
+		return
+	}
+	handler, ok := server.handlers[param.Method]
+	server.mu.Unlock()
+
+	if ok {
+		writeResp(w, handler(param))
+		return
+	}
+	writeResp(w, server.handle(param))
+}
+
+// nextFault pops the first fault matching method, callers hold mu.
+func (server *Server) nextFault(method model.Method) *Fault {
+	for i, fault := range server.faults {
+		if fault.Method != "" && fault.Method != method {
+			continue
+		}
+		fault.Times--
+		if fault.Times == 0 {
+			server.faults = append(server.faults[:i], server.faults[i+1:]...)
+		}
+		return fault
+	}
+	return nil
+}
+
+// validToken callers hold mu.
+func (server *Server) validToken(token string) bool {
+	issued, ok := server.tokens[token]
+	if !ok {
+		return false
+	}
+	if server.tokenTTL > 0 && time.Since(issued) > server.tokenTTL {
+		delete(server.tokens, token)
+		return false
+	}
+	return true
+}
+
+func (server *Server) handle(param model.CallRestBizParam) response.BaseResp {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	if server.frozen[param.TenantId] && param.Method != model.FROZENTENANT && param.Method != model.UNFROZENTENANT {
+		return response.BaseResp{Code: "400", Data: fmt.Sprintf("tenant %v is frozen", param.TenantId)}
+	}
+	switch param.Method {
+	case model.DEPOSIT, model.DEPOSITWITHADMIN:
+		return success(server.commit(param, nil).Hash)
+	case model.FROZENTENANT, model.UNFROZENTENANT:
+		server.frozen[param.TenantId] = param.Method == model.FROZENTENANT
+		return success("")
+	case model.REGISTERBLOCKCHAINCONFIG:
+		config := model.BlockchainConfig{}
+		if err := json.Unmarshal([]byte(param.RequestStr), &config); err != nil {
+			return response.BaseResp{Code: "400", Data: err.Error()}
+		}
+		server.chains[param.BizId] = config
+		return success("")
+	case model.CREATEACCOUNT:
+		server.accounts[param.Account] = &Account{Name: param.Account, MykmsKeyId: param.MykmsKeyId, TenantId: param.TenantId}
+		return success(server.commit(param, nil).Hash)
+	case model.QUERYACCOUNT:
+		return server.queryAccount(param)
+	case model.FREEZEACCOUNTASYN, model.UNFREEZEACCOUNTASYN:
+		account, ok := server.accounts[param.ToAccount]
+		if !ok {
+			return response.BaseResp{Code: "400", Data: fmt.Sprintf("account %v not found", param.ToAccount)}
+		}
+		account.Status = 0
+		if param.Method == model.FREEZEACCOUNTASYN {
+			account.Status = 1
+		}
+		return success(server.commit(param, nil).Hash)
+	case model.DEPLOYCONTRACT, model.DEPLOYCONTRACTFORBIZ, model.DEPLOYWASMCONTRACT, model.DEPLOYNATIVECONTRACT,
+		model.UPDATECONTRACT, model.UPDATECONTRACTFORBIZ:
+		return success(server.commit(param, nil).Hash)
+	case model.CALLCONTRACT, model.CALLCONTRACTBIZ, model.CALLCONTRACTBIZASYNC,
+		model.CALLWASMCONTRACT, model.CALLWASMCONTRACTASYNC,
+		model.CALLNATIVECONTRACT, model.CALLNATIVECONTRACTASYNC,
+		model.CALLNATIVECONTRACTFORBIZ, model.CALLNATIVECONTRACTFORBIZASYNC:
+		return server.callContract(param)
+	case model.QUERYRECEIPT, model.QUERYRECEIPTBIZ:
+		return server.queryReceipt(param)
+	case model.QUERYTRANSACTION, model.QUERYTRANSACTIONBIZ:
+		return server.queryTransaction(param)
+	case model.QUERYBLOCK, model.QUERYBLOCKBODY, model.QUERYBLOCKHEADERINFOSRAW, model.QUERYLASTBLOCK:
+		if _, ok := server.newChains[param.BizId]; ok {
+			return server.queryNewChain(param)
+		}
+		return server.queryBlock(param)
+	case model.INVITEUSER:
+		request := model.InviteUserRequest{}
+		if err := json.Unmarshal([]byte(param.RequestStr), &request); err != nil {
+			return response.BaseResp{Code: "400", Data: err.Error()}
+		}
+		return successJson(model.Invitation{
+			InviteId:   randomHex(8),
+			BizId:      param.BizId,
+			TenantId:   request.TenantId,
+			Role:       request.Role,
+			Status:     "INVITED",
+			CreateTime: time.Now().UnixNano() / 1e6,
+		})
+	case model.SIGNHASH:
+		for _, account := range server.accounts {
+			if account.MykmsKeyId == param.MykmsKeyId {
+				signature := sha256.Sum256([]byte(param.MykmsKeyId + param.RequestStr))
+				return successJson(model.SignHashResult{MykmsKeyId: param.MykmsKeyId, Signature: hex.EncodeToString(signature[:])})
+			}
+		}
+		return response.BaseResp{Code: "400", Data: fmt.Sprintf("kms key %v not found", param.MykmsKeyId)}
+	case model.PARSEOUTPUT:
+		return parseOutput(param)
+	case model.NEWCHAIN:
+		request := model.NewChainRequest{}
+		if err := json.Unmarshal([]byte(param.RequestStr), &request); err != nil {
+			return response.BaseResp{Code: "400", Data: err.Error()}
+		}
+		chain := model.Chain{BizId: randomHex(16), Name: request.Name, Status: "CREATING", CreateTime: time.Now().UnixNano() / 1e6}
+		server.newChains[chain.BizId] = server.chainPolls
+		return successJson(chain)
+	case model.GETEVENTTOPICBLOCKNUM:
+		blockNumber, ok := server.topics[param.Content]
+		if !ok {
+			return response.BaseResp{Code: model.ServiceQueryNoResult, Data: "no topic"}
+		}
+		return success(strconv.FormatInt(blockNumber, 10))
+	case model.GETMYTFINFO:
+		return successJson(model.MytfInfo{PublicKey: hex.EncodeToString(identity("mytf")), Version: "1"})
+	case model.GETTAPPINFO, model.INSTALLTAPP, model.EXECUTETAPP, model.EXECUTETAPPPRIVATE:
+		return server.tapp(param)
+	case model.GETRESOURCEMAP, model.SETRESOURCEMAP, model.UPDATERESOURCEMAP:
+		return server.resourceMap(param)
+	case model.UPDATEEVENTTOPICBLOCKNUM:
+		server.topics[param.Content] = param.BlockNumber
+		return success("")
+	case model.APPLYKEY, model.RESETAPPLYKEY, model.QUERYACCESSLIST:
+		return server.accessKey(param)
+	case model.QUERYTENANTKMSLIST:
+		kmsKeys := make([]model.KmsKey, 0)
+		for _, account := range server.accounts {
+			if account.MykmsKeyId != "" && account.TenantId == param.TenantId {
+				kmsKeys = append(kmsKeys, model.KmsKey{
+					MykmsKeyId: account.MykmsKeyId,
+					TenantId:   account.TenantId,
+					Account:    account.Name,
+					Status:     model.AccessKeyEnabled,
+				})
+			}
+		}
+		sort.Slice(kmsKeys, func(i, j int) bool { return kmsKeys[i].MykmsKeyId < kmsKeys[j].MykmsKeyId })
+		return successJson(kmsKeys)
+	}
+	return response.BaseResp{Code: "400", Data: fmt.Sprintf("method %v is not supported by resttest", param.Method)}
+}
+
+func (server *Server) queryAccount(param model.CallRestBizParam) response.BaseResp {
+	accountRequest := model.AccountRequest{}
+	if err := json.Unmarshal([]byte(param.RequestStr), &accountRequest); err != nil {
+		return response.BaseResp{Code: "400", Data: err.Error()}
+	}
+	account, ok := server.accounts[accountRequest.QueryAccount]
+	if !ok {
+		return response.BaseResp{Code: model.ServiceQueryNoResult, Data: "account not found"}
+	}
+	return successJson(map[string]interface{}{
+		"id":     hex.EncodeToString(identity(account.Name)),
+		"status": account.Status,
+	})
+}
+
+func (server *Server) callContract(param model.CallRestBizParam) response.BaseResp {
+	var outRes []interface{}
+	var output []byte
+	var revert *Revert
+	if contract, ok := server.contracts[param.ContractName]; ok {
+		var err error
+		outRes, output, err = contract(param)
+		if err != nil && !errors.As(err, &revert) {
+			return response.BaseResp{Code: "400", Data: err.Error()}
+		}
+	}
+	tx := server.commit(param, output)
+	if revert != nil {
+		tx.Result = revert.Result
+	}
+	switch param.Method {
+	case model.CALLCONTRACTBIZASYNC, model.CALLWASMCONTRACTASYNC,
+		model.CALLNATIVECONTRACTASYNC, model.CALLNATIVECONTRACTFORBIZASYNC:
+		return success(tx.Hash)
+	}
+	if revert != nil {
+		return response.BaseResp{Code: "400", Data: revert.Error()}
+	}
+	if outRes == nil {
+		outRes = make([]interface{}, 0)
+	}
+	return successJson(map[string]interface{}{"outRes": outRes})
+}
+
+func (server *Server) queryReceipt(param model.CallRestBizParam) response.BaseResp {
+	tx, ok := server.txs[param.Hash]
+	if !ok {
+		return response.BaseResp{Code: model.ServiceQueryNoResult, Data: "no receipt"}
+	}
+	if code, pending := server.pending(&tx.receiptPolls); pending {
+		return response.BaseResp{Code: code}
+	}
+	return successJson(receiptJson(tx))
+}
+
+func (server *Server) queryTransaction(param model.CallRestBizParam) response.BaseResp {
+	tx, ok := server.txs[param.Hash]
+	if !ok {
+		return response.BaseResp{Code: model.ServiceQueryNoResult, Data: "no transaction"}
+	}
+	if code, pending := server.pending(&tx.transactionPolls); pending {
+		return response.BaseResp{Code: code}
+	}
+	return successJson(map[string]interface{}{
+		"blockNumber":   tx.BlockNumber,
+		"transactionDO": transactionJson(tx),
+	})
+}
+
+// transactionJson renders tx as mychain returns transactions, the nonce is the block number and the
+// only signature is a digest of the sender and the hash.
+func transactionJson(tx *Tx) map[string]interface{} {
+	signature := sha256.Sum256([]byte(tx.Param.Account + tx.Hash))
+	return map[string]interface{}{
+		"hash":          tx.Hash,
+		"from":          hex.EncodeToString(identity(tx.Param.Account)),
+		"to":            hex.EncodeToString(identity(tx.Param.ContractName)),
+		"nonce":         tx.BlockNumber,
+		"timestamp":     tx.Timestamp,
+		"data":          base64.StdEncoding.EncodeToString([]byte(tx.Param.Content)),
+		"signatureList": []string{hex.EncodeToString(signature[:])},
+	}
+}
+
+// receiptJson renders the receipt of tx, the only transaction of its block.
+func receiptJson(tx *Tx) map[string]interface{} {
+	return map[string]interface{}{
+		"hash":             tx.Hash,
+		"blockNumber":      tx.BlockNumber,
+		"transactionIndex": 0,
+		"result":           tx.Result,
+		"gasUsed":          tx.Param.Gas,
+		"output":           base64.StdEncoding.EncodeToString(tx.Output),
+		"logs":             tx.Logs,
+	}
+}
+
+// tapp serves the TAPP methods, versions of a tapp are installed in order.
+func (server *Server) tapp(param model.CallRestBizParam) response.BaseResp {
+	request := model.TappExecuteRequest{}
+	if err := json.Unmarshal([]byte(param.RequestStr), &request); err != nil {
+		return response.BaseResp{Code: "400", Data: err.Error()}
+	}
+	versions := server.tapps[request.TappId]
+	if param.Method == model.INSTALLTAPP {
+		install := model.TappInstallRequest{}
+		if err := json.Unmarshal([]byte(param.RequestStr), &install); err != nil {
+			return response.BaseResp{Code: "400", Data: err.Error()}
+		}
+		if install.Version <= int64(len(versions)) {
+			return response.BaseResp{Code: "400", Data: fmt.Sprintf("tapp %v version %v is installed", install.TappId, install.Version)}
+		}
+		codeHash := sha256.Sum256([]byte(install.Code))
+		server.tapps[install.TappId] = append(versions, model.TappInfo{
+			TappId:      install.TappId,
+			Version:     install.Version,
+			Owner:       hex.EncodeToString(identity(param.Account)),
+			CodeHash:    hex.EncodeToString(codeHash[:]),
+			Description: install.Description,
+		})
+		return success(server.commit(param, nil).Hash)
+	}
+	if len(versions) == 0 || request.Version > int64(len(versions)) {
+		return response.BaseResp{Code: model.ServiceQueryNoResult, Data: fmt.Sprintf("tapp %v is not installed", request.TappId)}
+	}
+	if param.Method == model.GETTAPPINFO {
+		if request.Version == 0 {
+			return successJson(versions[len(versions)-1])
+		}
+		return successJson(versions[request.Version-1])
+	}
+	var output []byte
+	if contract, ok := server.contracts[request.TappId]; ok {
+		outRes, _, err := contract(param)
+		if err != nil {
+			return response.BaseResp{Code: "400", Data: err.Error()}
+		}
+		if output, err = json.Marshal(outRes); err != nil {
+			return response.BaseResp{Code: "400", Data: err.Error()}
+		}
+	}
+	tx := server.commit(param, output)
+	return successJson(model.TappResult{Hash: tx.Hash, Output: string(output)})
+}
+
+// resourceMap serves the resource map methods.
+func (server *Server) resourceMap(param model.CallRestBizParam) response.BaseResp {
+	resourceMap := model.ResourceMap{}
+	if err := json.Unmarshal([]byte(param.RequestStr), &resourceMap); err != nil {
+		return response.BaseResp{Code: "400", Data: err.Error()}
+	}
+	current, ok := server.resources[resourceMap.Name]
+	switch param.Method {
+	case model.GETRESOURCEMAP:
+		if !ok {
+			return response.BaseResp{Code: model.ServiceQueryNoResult, Data: fmt.Sprintf("resource map %v not found", resourceMap.Name)}
+		}
+		return successJson(current)
+	case model.UPDATERESOURCEMAP:
+		if !ok {
+			return response.BaseResp{Code: model.ServiceQueryNoResult, Data: fmt.Sprintf("resource map %v not found", resourceMap.Name)}
+		}
+	}
+	server.resources[resourceMap.Name] = resourceMap
+	return success("")
+}
+
+// accessKey serves the access key methods, applied keys are enabled at once.
+func (server *Server) accessKey(param model.CallRestBizParam) response.BaseResp {
+	switch param.Method {
+	case model.APPLYKEY:
+		accessKey := &model.AccessKey{
+			AccessId:   randomHex(8),
+			TenantId:   param.TenantId,
+			PublicKey:  param.ApplyAccessKey,
+			Status:     model.AccessKeyEnabled,
+			CreateTime: time.Now().UnixNano() / 1e6,
+		}
+		server.accessKeys[accessKey.AccessId] = accessKey
+		return successJson(accessKey)
+	case model.RESETAPPLYKEY:
+		accessKey, ok := server.accessKeys[param.RequestStr]
+		if !ok || accessKey.TenantId != param.TenantId {
+			return response.BaseResp{Code: model.ServiceQueryNoResult, Data: fmt.Sprintf("access key %v not found", param.RequestStr)}
+		}
+		accessKey.PublicKey = param.ApplyAccessKey
+		return successJson(accessKey)
+	}
+	accessKeys := make([]model.AccessKey, 0)
+	for _, accessKey := range server.accessKeys {
+		if param.TenantId == "" || accessKey.TenantId == param.TenantId {
+			accessKeys = append(accessKeys, *accessKey)
+		}
+	}
+	sort.Slice(accessKeys, func(i, j int) bool { return accessKeys[i].AccessId < accessKeys[j].AccessId })
+	return successJson(accessKeys)
+}
+
+// parseOutput decodes the base64 encoded abi output in RequestStr according to OutTypes.
+func parseOutput(param model.CallRestBizParam) response.BaseResp {
+	types := make([]string, 0)
+	if err := json.Unmarshal([]byte(param.OutTypes), &types); err != nil {
+		return response.BaseResp{Code: "400", Data: fmt.Sprintf("invalid outTypes %v", param.OutTypes)}
+	}
+	arguments := make(abi.Arguments, len(types))
+	for i, t := range types {
+		argumentType, err := abi.NewType(t)
+		if err != nil {
+			return response.BaseResp{Code: "400", Data: err.Error()}
+		}
+		arguments[i] = abi.Argument{Type: argumentType}
+	}
+	output, err := base64.StdEncoding.DecodeString(param.RequestStr)
+	if err != nil {
+		return response.BaseResp{Code: "400", Data: err.Error()}
+	}
+	outRes, err := arguments.UnpackValues(output)
+	if err != nil {
+		return response.BaseResp{Code: "400", Data: err.Error()}
+	}
+	return successJson(map[string]interface{}{"outRes": outRes})
+}
+
+// queryNewChain serves the block queries of a chain created through NEWCHAIN, it only holds its
+// genesis block once ready.
+func (server *Server) queryNewChain(param model.CallRestBizParam) response.BaseResp {
+	if server.newChains[param.BizId] > 0 {
+		server.newChains[param.BizId]--
+		return response.BaseResp{Code: model.ServiceQueryNoResult, Data: "chain is not ready"}
+	}
+	if param.Method != model.QUERYLASTBLOCK && param.RequestStr != "0" {
+		return response.BaseResp{Code: model.ServiceQueryNoResult, Data: "no block"}
+	}
+	header := map[string]interface{}{
+		"number":     0,
+		"hash":       hex.EncodeToString(identity(param.BizId)),
+		"parentHash": hex.EncodeToString(make([]byte, sha256.Size)),
+	}
+	switch param.Method {
+	case model.QUERYBLOCKHEADERINFOSRAW:
+		return successJson(header)
+	case model.QUERYBLOCKBODY:
+		return successJson(map[string]interface{}{})
+	}
+	return successJson(map[string]interface{}{"blockHeader": header, "blockBody": map[string]interface{}{}})
+}
+
+// queryBlock serves the block queries, every committed transaction is a block of its own numbered
+// from 1.
+func (server *Server) queryBlock(param model.CallRestBizParam) response.BaseResp {
+	number := int64(len(server.txList))
+	if param.Method != model.QUERYLASTBLOCK {
+		var err error
+		if number, err = strconv.ParseInt(param.RequestStr, 10, 64); err != nil {
+			return response.BaseResp{Code: "400", Data: fmt.Sprintf("invalid block number %v", param.RequestStr)}
+		}
+	}
+	if number < 0 || number > int64(len(server.txList)) || (number == 0 && param.Method == model.QUERYLASTBLOCK) {
+		return response.BaseResp{Code: model.ServiceQueryNoResult, Data: "no block"}
+	}
+	// block 0 is the genesis block, without transactions
+	genesis := &Tx{}
+	tx := genesis
+	if number > 0 {
+		tx = server.txList[number-1]
+	}
+	parentHash := make([]byte, sha256.Size)
+	if number > 1 {
+		parentHash = blockHash(server.txList[number-2])
+	} else if number == 1 {
+		parentHash = blockHash(genesis)
+	}
+	header := map[string]interface{}{
+		"number":          tx.BlockNumber,
+		"hash":            hex.EncodeToString(blockHash(tx)),
+		"parentHash":      hex.EncodeToString(parentHash),
+		"timestamp":       tx.Timestamp,
+		"transactionRoot": tx.Hash,
+		"gasUsed":         tx.Param.Gas,
+	}
+	body := map[string]interface{}{
+		"transactionList": []interface{}{},
+		"receiptList":     []interface{}{},
+	}
+	if number > 0 {
+		body["transactionList"] = []interface{}{transactionJson(tx)}
+		body["receiptList"] = []interface{}{receiptJson(tx)}
+	}
+	switch param.Method {
+	case model.QUERYBLOCKHEADERINFOSRAW:
+		return successJson(header)
+	case model.QUERYBLOCKBODY:
+		return successJson(body)
+	}
+	return successJson(map[string]interface{}{"blockHeader": header, "blockBody": body})
+}
+
+func blockHash(tx *Tx) []byte {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("block%v%v", tx.BlockNumber, tx.Hash)))
+	return sum[:]
+}
+
+// pending advances polls and reports the waiting code, callers hold mu.
+func (server *Server) pending(polls *int) (string, bool) {
+	*polls++
+	if *polls <= server.pendingVerify {
+		return model.ServiceTxWaitingVerify, true
+	}
+	if *polls <= server.pendingVerify+server.pendingExecute {
+		return model.ServiceTxWaitingExecute, true
+	}
+	return "", false
+}
+
+// commit callers hold mu.
+func (server *Server) commit(param model.CallRestBizParam, output []byte) *Tx {
+	param.Token = ""
+	tx := &Tx{
+		Hash:        fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprintf("%v%v%v", param.OrderId, len(server.txList), randomHex(8))))),
+		BlockNumber: int64(len(server.txList) + 1),
+		Param:       param,
+		Output:      output,
+		Timestamp:   time.Now().UnixNano() / 1e6,
+	}
+	server.txs[tx.Hash] = tx
+	server.txList = append(server.txList, tx)
+	return tx
+}
+
+func identity(name string) []byte {
+	sum := sha256.Sum256([]byte(name))
+	return sum[:]
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+func success(data string) response.BaseResp {
+	return response.BaseResp{Success: true, Code: model.ServiceSuccess, Data: data}
+}
+
+func successJson(data interface{}) response.BaseResp {
+	jsonStr, err := json.Marshal(data)
+	if err != nil {
+		return response.BaseResp{Code: "500", Data: err.Error()}
+	}
+	return success(string(jsonStr))
+}
+
+func writeResp(w http.ResponseWriter, baseResp response.BaseResp) {
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	json.NewEncoder(w).Encode(&baseResp)
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+)
+
+func (client *RestClient) SignHash(bizid, tenantId, kmsId string, hash []byte) ([]byte, error) {
+	return client.SignHashWithContext(context.Background(), bizid, tenantId, kmsId, hash)
+}
+
+// SignHashWithContext has the kms sign hash with the key kmsId of tenantId and returns the signature.
+// hash is signed as is, callers hash the message themselves.
+func (client *RestClient) SignHashWithContext(ctx context.Context, bizid, tenantId, kmsId string, hash []byte) ([]byte, error) {
+	if len(hash) == 0 {
+		return nil, response.NewValidationError(fmt.Sprintf("%v method must has hash", model.SIGNHASH))
+	}
+	result := model.SignHashResult{}
+	err := client.chainCallForBizResult(ctx, model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId:   client.RestClientProperties.AccessId,
+			BizId:      bizid,
+			Method:     model.SIGNHASH,
+			RequestStr: hex.EncodeToString(hash),
+		},
+		TenantId:   tenantId,
+		MykmsKeyId: kmsId,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := hex.DecodeString(result.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("%v returned an invalid signature,err:%w", model.SIGNHASH, err)
+	}
+	return signature, nil
+}
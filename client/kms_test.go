@@ -0,0 +1,28 @@
+package client
+
+import (
+	"crypto/sha256"
+	"errors"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSignHash(t *testing.T) {
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	hash := sha256.Sum256([]byte("message"))
+	signature, err := restClient.SignHash(RestBizTestBizID, RestBizTestTenantID, RestBizTestKmsID, hash[:])
+	require.NoError(t, err)
+	require.NotEmpty(t, signature)
+	again, err := restClient.SignHash(RestBizTestBizID, RestBizTestTenantID, RestBizTestKmsID, hash[:])
+	require.NoError(t, err)
+	require.Equal(t, signature, again)
+
+	_, err = restClient.SignHash(RestBizTestBizID, RestBizTestTenantID, RestBizTestKmsID, nil)
+	require.Truef(t, errors.Is(err, response.ErrValidation), "expect validation err:%+v", err)
+	_, err = restClient.SignHash(RestBizTestBizID, RestBizTestTenantID, "", hash[:])
+	require.Truef(t, errors.Is(err, response.ErrValidation), "expect validation err:%+v", err)
+	_, err = restClient.SignHash(RestBizTestBizID, RestBizTestTenantID, "missing_kms_id", hash[:])
+	require.Truef(t, errors.Is(err, response.ErrUnsuccessfulResult), "expect unsuccessful result err:%+v", err)
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"github.com/ctwel/antchain-client-go-sdk/utils"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+	"time"
+)
+
+// Clock supplies the current time, mainly the shake hand timestamp.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Option customizes a RestClient built by one of the NewRestClient constructors.
+type Option func(client *RestClient)
+
+// WithHTTPClient replaces the http.Client built from MaxIdleConns and IdleConnTimeout.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(client *RestClient) {
+		client.httpClient = httpClient
+	}
+}
+
+// WithRetryPolicy replaces the exponential backoff policy built from RetryMaxAttempts and BackOffPeriod.
+func WithRetryPolicy(retryPolicy RetryPolicy) Option {
+	return func(client *RestClient) {
+		client.retryPolicy = retryPolicy
+	}
+}
+
+// WithLogger replaces the standard logrus logger, a nil logger keeps it.
+func WithLogger(logger log.FieldLogger) Option {
+	return func(client *RestClient) {
+		if logger != nil {
+			client.logger = logger
+		}
+	}
+}
+
+// WithSigner replaces the signer reading the AccessSecret key file.
+func WithSigner(signer utils.Signer) Option {
+	return func(client *RestClient) {
+		client.signer = signer
+	}
+}
+
+// WithClock replaces the system clock.
+func WithClock(clock Clock) Option {
+	return func(client *RestClient) {
+		client.clock = clock
+	}
+}
+
+// WithAdmin enables the admin methods, see RestClient.Admin. Clients built without it refuse them.
+func WithAdmin() Option {
+	return func(client *RestClient) {
+		client.admin = true
+	}
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+)
+
+// parseOutputResult is the Data of a PARSEOUTPUT response, the values are kept raw so that integers
+// are decoded as json.Number.
+type parseOutputResult struct {
+	OutRes json.RawMessage `json:"outRes"`
+}
+
+func (client *RestClient) ParseOutput(bizid, outTypes string, output []byte) ([]interface{}, error) {
+	return client.ParseOutputWithContext(context.Background(), bizid, outTypes, output)
+}
+
+// ParseOutputWithContext has the rest server decode the contract output, as found base64 decoded in a
+// receipt, according to outTypes, a json array of solidity types such as abi.Method.OutTypes returns.
+// Integers are returned as json.Number, bytes base64 encoded and identities hex encoded, like the
+// outRes of a synchronous call.
+func (client *RestClient) ParseOutputWithContext(ctx context.Context, bizid, outTypes string, output []byte) ([]interface{}, error) {
+	if outTypes == "" {
+		return nil, response.NewValidationError(fmt.Sprintf("%v method must has outTypes", model.PARSEOUTPUT))
+	}
+	result := parseOutputResult{}
+	err := client.chainCallForBizResult(ctx, model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId:   client.RestClientProperties.AccessId,
+			BizId:      bizid,
+			Method:     model.PARSEOUTPUT,
+			RequestStr: base64.StdEncoding.EncodeToString(output),
+		},
+		OutTypes: outTypes,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(result.OutRes))
+	decoder.UseNumber()
+	outRes := make([]interface{}, 0)
+	if err := decoder.Decode(&outRes); err != nil {
+		return nil, fmt.Errorf("%v returned an invalid outRes,err:%w", model.PARSEOUTPUT, err)
+	}
+	return outRes, nil
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/ctwel/antchain-client-go-sdk/abi"
+	"github.com/ctwel/antchain-client-go-sdk/mychain"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"testing"
+)
+
+func TestParseOutput(t *testing.T) {
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	outTypes := `["uint256","string","identity"]`
+	types := make([]string, 0)
+	require.NoError(t, json.Unmarshal([]byte(outTypes), &types))
+	arguments := make(abi.Arguments, len(types))
+	for i, typ := range types {
+		argumentType, err := abi.NewType(typ)
+		require.NoError(t, err)
+		arguments[i] = abi.Argument{Type: argumentType}
+	}
+	n, ok := new(big.Int).SetString("123456789012345678901234567890", 10)
+	require.True(t, ok)
+	identity := mychain.NewIdentity(RestBizTestAccount)
+	output, err := arguments.Pack(n, "hello", identity)
+	require.NoError(t, err)
+
+	outRes, err := restClient.ParseOutput(RestBizTestBizID, outTypes, output)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{json.Number(n.String()), "hello", identity.Hex()}, outRes)
+
+	_, err = restClient.ParseOutput(RestBizTestBizID, "", output)
+	require.Truef(t, errors.Is(err, response.ErrValidation), "expect validation err:%+v", err)
+	_, err = restClient.ParseOutput(RestBizTestBizID, `["uint256"]`, []byte{1})
+	require.Truef(t, errors.Is(err, response.ErrUnsuccessfulResult), "expect unsuccessful result err:%+v", err)
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+)
+
+// ErrResourceMapConflict is returned by ModifyResourceMap when the map changed while it was modified.
+var ErrResourceMapConflict = errors.New("resource map changed concurrently")
+
+func (client *RestClient) GetResourceMap(bizid, name string) (model.ResourceMap, error) {
+	return client.GetResourceMapWithContext(context.Background(), bizid, name)
+}
+
+// GetResourceMapWithContext queries the resource map name, an error wrapping response.ErrNotFound is
+// returned if it does not exist.
+func (client *RestClient) GetResourceMapWithContext(ctx context.Context, bizid, name string) (model.ResourceMap, error) {
+	if name == "" {
+		return model.ResourceMap{}, response.NewValidationError(fmt.Sprintf("%v method must has name", model.GETRESOURCEMAP))
+	}
+	resourceMap := model.ResourceMap{}
+	err := client.callResourceMap(ctx, model.GETRESOURCEMAP, bizid, "", model.ResourceMapRequest{Name: name}, &resourceMap)
+	return resourceMap, err
+}
+
+func (client *RestClient) SetResourceMap(bizid, orderId string, resourceMap model.ResourceMap) error {
+	return client.SetResourceMapWithContext(context.Background(), bizid, orderId, resourceMap)
+}
+
+// SetResourceMapWithContext creates the resource map resourceMap.Name, or replaces its entries if it
+// exists.
+func (client *RestClient) SetResourceMapWithContext(ctx context.Context, bizid, orderId string, resourceMap model.ResourceMap) error {
+	if resourceMap.Name == "" {
+		return response.NewValidationError(fmt.Sprintf("%v method must has name", model.SETRESOURCEMAP))
+	}
+	return client.callResourceMap(ctx, model.SETRESOURCEMAP, bizid, orderId, resourceMap, nil)
+}
+
+func (client *RestClient) UpdateResourceMap(bizid, orderId string, resourceMap model.ResourceMap) error {
+	return client.UpdateResourceMapWithContext(context.Background(), bizid, orderId, resourceMap)
+}
+
+// UpdateResourceMapWithContext replaces the entries of the existing resource map resourceMap.Name.
+func (client *RestClient) UpdateResourceMapWithContext(ctx context.Context, bizid, orderId string, resourceMap model.ResourceMap) error {
+	if resourceMap.Name == "" {
+		return response.NewValidationError(fmt.Sprintf("%v method must has name", model.UPDATERESOURCEMAP))
+	}
+	return client.callResourceMap(ctx, model.UPDATERESOURCEMAP, bizid, orderId, resourceMap, nil)
+}
+
+func (client *RestClient) ModifyResourceMap(bizid, orderId, name string, modify func(entries map[string]string) error) (model.ResourceMap, error) {
+	return client.ModifyResourceMapWithContext(context.Background(), bizid, orderId, name, modify)
+}
+
+// ModifyResourceMapWithContext reads name, lets modify change a copy of its entries and writes them back
+// once with orderId, with UPDATERESOURCEMAP or with SETRESOURCEMAP for a map that does not exist yet,
+// which is modified from no entries. Nothing is written when modify leaves the entries unchanged.
+// The map is read again right before the write, and if it changed since the first read nothing is
+// written and an error wrapping ErrResourceMapConflict is returned, the caller may then modify again
+// with a new orderId. The rest server writes unconditionally, so a change landing between that second
+// read and the write is still overwritten. It returns the map as written.
+func (client *RestClient) ModifyResourceMapWithContext(ctx context.Context, bizid, orderId, name string, modify func(entries map[string]string) error) (model.ResourceMap, error) {
+	current, exists, err := client.readResourceMap(ctx, bizid, name)
+	if err != nil {
+		return model.ResourceMap{}, err
+	}
+	entries := make(map[string]string, len(current.Entries))
+	for key, value := range current.Entries {
+		entries[key] = value
+	}
+	if err := modify(entries); err != nil {
+		return model.ResourceMap{}, err
+	}
+	if exists && equalEntries(current.Entries, entries) {
+		return current, nil
+	}
+	latest, latestExists, err := client.readResourceMap(ctx, bizid, name)
+	if err != nil {
+		return model.ResourceMap{}, err
+	}
+	if latestExists != exists || !equalEntries(current.Entries, latest.Entries) {
+		return model.ResourceMap{}, fmt.Errorf("%w,name:%v", ErrResourceMapConflict, name)
+	}
+	method := model.Method(model.UPDATERESOURCEMAP)
+	if !exists {
+		method = model.SETRESOURCEMAP
+	}
+	modified := model.ResourceMap{Name: name, Entries: entries}
+	if err := client.callResourceMap(ctx, method, bizid, orderId, modified, nil); err != nil {
+		return model.ResourceMap{}, err
+	}
+	return modified, nil
+}
+
+// readResourceMap reads name, a map that does not exist is returned empty with false.
+func (client *RestClient) readResourceMap(ctx context.Context, bizid, name string) (model.ResourceMap, bool, error) {
+	resourceMap, err := client.GetResourceMapWithContext(ctx, bizid, name)
+	if errors.Is(err, response.ErrNotFound) {
+		return model.ResourceMap{Name: name}, false, nil
+	}
+	if err != nil {
+		return model.ResourceMap{}, false, err
+	}
+	return resourceMap, true, nil
+}
+
+func equalEntries(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (client *RestClient) callResourceMap(ctx context.Context, method model.Method, bizid, orderId string, request interface{}, v interface{}) error {
+	requestStr, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	return client.chainCallForBizResult(ctx, model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId:   client.RestClientProperties.AccessId,
+			BizId:      bizid,
+			Method:     method,
+			RequestStr: string(requestStr),
+		},
+		OrderId: orderId,
+	}, v)
+}
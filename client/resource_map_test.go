@@ -0,0 +1,93 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestResourceMap(t *testing.T) {
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	name := fmt.Sprintf("test_resource_map_%v", uuid.New().String())
+	_, err := restClient.GetResourceMap(RestBizTestBizID, name)
+	require.Truef(t, errors.Is(err, response.ErrNotFound), "expect not found err:%+v", err)
+	orderId := fmt.Sprintf("order_%v", uuid.New().String())
+	err = restClient.UpdateResourceMap(RestBizTestBizID, orderId, model.ResourceMap{Name: name, Entries: map[string]string{"a": "1"}})
+	require.Truef(t, errors.Is(err, response.ErrNotFound), "expect not found err:%+v", err)
+
+	resourceMap := model.ResourceMap{Name: name, Entries: map[string]string{"a": "1", "b": "2"}}
+	require.NoError(t, restClient.SetResourceMap(RestBizTestBizID, orderId, resourceMap))
+	got, err := restClient.GetResourceMap(RestBizTestBizID, name)
+	require.NoError(t, err)
+	require.Equal(t, resourceMap, got)
+
+	orderId = fmt.Sprintf("order_%v", uuid.New().String())
+	resourceMap = model.ResourceMap{Name: name, Entries: map[string]string{"b": "2", "c": "3"}}
+	require.NoError(t, restClient.UpdateResourceMap(RestBizTestBizID, orderId, resourceMap))
+	got, err = restClient.GetResourceMap(RestBizTestBizID, name)
+	require.NoError(t, err)
+	require.Equal(t, resourceMap, got)
+
+	err = restClient.SetResourceMap(RestBizTestBizID, orderId, model.ResourceMap{})
+	require.True(t, errors.Is(err, response.ErrValidation))
+	err = restClient.UpdateResourceMap(RestBizTestBizID, "", resourceMap)
+	require.True(t, errors.Is(err, response.ErrValidation))
+}
+
+func TestModifyResourceMap(t *testing.T) {
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	name := fmt.Sprintf("test_resource_map_%v", uuid.New().String())
+	orderId := fmt.Sprintf("order_%v", uuid.New().String())
+	resourceMap, err := restClient.ModifyResourceMap(RestBizTestBizID, orderId, name, func(entries map[string]string) error {
+		entries["count"] = "1"
+		entries["old"] = "x"
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, model.ResourceMap{Name: name, Entries: map[string]string{"count": "1", "old": "x"}}, resourceMap)
+	require.Equal(t, 1, server.Calls(model.SETRESOURCEMAP))
+
+	orderId = fmt.Sprintf("order_%v", uuid.New().String())
+	resourceMap, err = restClient.ModifyResourceMap(RestBizTestBizID, orderId, name, func(entries map[string]string) error {
+		entries["count"] += "0"
+		delete(entries, "old")
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, model.ResourceMap{Name: name, Entries: map[string]string{"count": "10"}}, resourceMap)
+	got, err := restClient.GetResourceMap(RestBizTestBizID, name)
+	require.NoError(t, err)
+	require.Equal(t, resourceMap, got)
+	require.Equal(t, 1, server.Calls(model.UPDATERESOURCEMAP))
+
+	// a change made while modify runs is reported instead of overwritten
+	orderId = fmt.Sprintf("order_%v", uuid.New().String())
+	_, err = restClient.ModifyResourceMap(RestBizTestBizID, orderId, name, func(entries map[string]string) error {
+		require.NoError(t, restClient.SetResourceMap(RestBizTestBizID, orderId+"_set", model.ResourceMap{Name: name, Entries: map[string]string{"count": "5"}}))
+		entries["count"] += "0"
+		return nil
+	})
+	require.Truef(t, errors.Is(err, ErrResourceMapConflict), "expect conflict err:%+v", err)
+	require.Equal(t, 1, server.Calls(model.UPDATERESOURCEMAP))
+	got, err = restClient.GetResourceMap(RestBizTestBizID, name)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"count": "5"}, got.Entries)
+
+	errStop := errors.New("stop")
+	_, err = restClient.ModifyResourceMap(RestBizTestBizID, orderId, name, func(entries map[string]string) error {
+		return errStop
+	})
+	require.True(t, errors.Is(err, errStop))
+	resourceMap, err = restClient.ModifyResourceMap(RestBizTestBizID, orderId, name, func(entries map[string]string) error {
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, got, resourceMap)
+	require.Equal(t, 1, server.Calls(model.UPDATERESOURCEMAP), "an unchanged map is not written")
+}
@@ -2,17 +2,23 @@ package client
 
 import (
 	"bytes"
+	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
-	log "github.com/sirupsen/logrus"
+	"github.com/ctwel/antchain-client-go-sdk/abi"
 	"github.com/ctwel/antchain-client-go-sdk/client/config"
 	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/mychain"
 	"github.com/ctwel/antchain-client-go-sdk/response"
 	"github.com/ctwel/antchain-client-go-sdk/utils"
+	"github.com/ctwel/antchain-client-go-sdk/wasm"
+	log "github.com/sirupsen/logrus"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
-	"strings"
 	"time"
 )
 
@@ -21,6 +27,7 @@ var (
 	DefaultIdleConnTimeout  = 30
 	DefaultRetryMaxAttempts = 5
 	DefaultBackOffPeriod    = 500
+	DefaultMaxBackOffPeriod = 10000
 )
 
 const (
@@ -36,8 +43,16 @@ const (
 
 type RestClient struct {
 	RestClientProperties config.RestClientProperties
-	RestToken            string
-	httpClient           *http.Client
+	// Deprecated: RestToken is the token of the latest shake hand, kept for existing callers. It is
+	// written without synchronization, use CurrentToken when the client is shared by goroutines.
+	RestToken   string
+	tokens      *tokenManager
+	httpClient  *http.Client
+	retryPolicy RetryPolicy
+	logger      log.FieldLogger
+	signer      utils.Signer
+	clock       Clock
+	admin       bool
 }
 
 func init() {
@@ -52,8 +67,13 @@ func init() {
 	log.SetLevel(log.InfoLevel)
 }
 
-func NewRestClient(restClientPropertiesPath string) (*RestClient, error) {
-	data, err := ioutil.ReadFile(restClientPropertiesPath)
+func NewRestClient(restClientPropertiesPath string, opts ...Option) (*RestClient, error) {
+	return NewRestClientWithContext(context.Background(), restClientPropertiesPath, opts...)
+}
+
+// NewRestClientWithContext is like NewRestClient but the initial shake hand is bound to ctx.
+func NewRestClientWithContext(ctx context.Context, restClientPropertiesPath string, opts ...Option) (*RestClient, error) {
+	file, err := os.Open(restClientPropertiesPath)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"restClientPropertiesPath": restClientPropertiesPath,
@@ -61,8 +81,8 @@ func NewRestClient(restClientPropertiesPath string) (*RestClient, error) {
 		}).Error("fail to read restClientProperties")
 		return nil, err
 	}
-	restClientProperties := config.RestClientProperties{}
-	err = json.Unmarshal(data, &restClientProperties)
+	defer file.Close()
+	restClientProperties, err := config.Load(file)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"restClientPropertiesPath": restClientPropertiesPath,
@@ -70,33 +90,84 @@ func NewRestClient(restClientPropertiesPath string) (*RestClient, error) {
 		}).Error("fail to parse restClientProperties")
 		return nil, err
 	}
+	return NewRestClientFromPropertiesWithContext(ctx, restClientProperties, opts...)
+}
 
-	maxIdleConns := DefaultMaxIdleConns
-	if restClientProperties.MaxIdleConns != 0 {
-		maxIdleConns = restClientProperties.MaxIdleConns
-	}
-	idleConnTimeout := DefaultIdleConnTimeout
-	if restClientProperties.IdleConnTimeout != 0 {
-		idleConnTimeout = restClientProperties.IdleConnTimeout
+// NewRestClientFromReader builds a client from json encoded properties read from r.
+func NewRestClientFromReader(r io.Reader, opts ...Option) (*RestClient, error) {
+	restClientProperties, err := config.Load(r)
+	if err != nil {
+		return nil, err
 	}
-	tr := &http.Transport{
-		MaxIdleConns:    maxIdleConns,
-		IdleConnTimeout: time.Duration(idleConnTimeout) * time.Second,
+	return NewRestClientFromProperties(restClientProperties, opts...)
+}
+
+// NewRestClientFromEnv builds a client from the environment variables described by config.LoadFromEnv,
+// using config.DefaultEnvPrefix.
+func NewRestClientFromEnv(opts ...Option) (*RestClient, error) {
+	restClientProperties, err := config.LoadFromEnv(config.DefaultEnvPrefix)
+	if err != nil {
+		return nil, err
 	}
-	client := &http.Client{Transport: tr}
+	return NewRestClientFromProperties(restClientProperties, opts...)
+}
 
+func NewRestClientFromProperties(restClientProperties config.RestClientProperties, opts ...Option) (*RestClient, error) {
+	return NewRestClientFromPropertiesWithContext(context.Background(), restClientProperties, opts...)
+}
+
+// NewRestClientFromPropertiesWithContext applies opts over the defaults derived from
+// restClientProperties and shakes hand with the rest server bound to ctx.
+func NewRestClientFromPropertiesWithContext(ctx context.Context, restClientProperties config.RestClientProperties, opts ...Option) (*RestClient, error) {
 	restClient := &RestClient{
 		RestClientProperties: restClientProperties,
-		httpClient:           client,
+		logger:               log.StandardLogger(),
+		signer:               utils.NewFileSigner(restClientProperties.AccessSecret),
+		clock:                systemClock{},
+	}
+	for _, opt := range opts {
+		opt(restClient)
+	}
+	if restClient.retryPolicy == nil {
+		retryMaxAttempts := DefaultRetryMaxAttempts
+		if restClientProperties.RetryMaxAttempts != 0 {
+			retryMaxAttempts = restClientProperties.RetryMaxAttempts
+		}
+		backoffPeriod := DefaultBackOffPeriod
+		if restClientProperties.BackOffPeriod != 0 {
+			backoffPeriod = restClientProperties.BackOffPeriod
+		}
+		restClient.retryPolicy = NewExponentialRetryPolicy(retryMaxAttempts, time.Duration(backoffPeriod)*time.Millisecond, time.Duration(DefaultMaxBackOffPeriod)*time.Millisecond)
+	}
+	if restClient.httpClient == nil {
+		maxIdleConns := DefaultMaxIdleConns
+		if restClientProperties.MaxIdleConns != 0 {
+			maxIdleConns = restClientProperties.MaxIdleConns
+		}
+		idleConnTimeout := DefaultIdleConnTimeout
+		if restClientProperties.IdleConnTimeout != 0 {
+			idleConnTimeout = restClientProperties.IdleConnTimeout
+		}
+		tr := &http.Transport{
+			MaxIdleConns:    maxIdleConns,
+			IdleConnTimeout: time.Duration(idleConnTimeout) * time.Second,
+		}
+		restClient.httpClient = &http.Client{Transport: tr}
 	}
 
-	err = restClient.shake()
+	restClient.tokens = newTokenManager(restClient.shake, restClient.clock, time.Duration(restClientProperties.TokenTTL)*time.Second)
+	_, err := restClient.tokens.Refresh(ctx, "")
 	if err != nil {
 		return nil, err
 	}
 	return restClient, nil
 }
 
+// CurrentToken returns the token obtained by the latest shake hand.
+func (client *RestClient) CurrentToken() string {
+	return client.tokens.current()
+}
+
 func (client *RestClient) CreateQueryAccountParam(queryAccount string) (model.ClientParam, error) {
 	queryAccountRequest := model.AccountRequest{QueryAccount: queryAccount}
 
@@ -110,15 +181,15 @@ func (client *RestClient) CreateQueryAccountParam(queryAccount string) (model.Cl
 	return queryAccountParam, nil
 }
 
-func (client *RestClient) shake() error {
-	log.Info("start shake hand")
-	nowMill := time.Now().UnixNano() / 1e6
-	secret, err := utils.Sign(fmt.Sprintf("%v%v", client.RestClientProperties.AccessId, nowMill), client.RestClientProperties.AccessSecret)
+func (client *RestClient) shake(ctx context.Context) (string, error) {
+	client.logger.Info("start shake hand")
+	nowMill := client.clock.Now().UnixNano() / 1e6
+	secret, err := client.signer.Sign(fmt.Sprintf("%v%v", client.RestClientProperties.AccessId, nowMill))
 	if err != nil {
-		log.WithFields(log.Fields{
+		client.logger.WithFields(log.Fields{
 			"err": err.Error(),
 		}).Error("fail to sign secret")
-		return err
+		return "", err
 	}
 	shakeRequest := &model.ShakeRequest{
 		AccessId: client.RestClientProperties.AccessId,
@@ -127,254 +198,352 @@ func (client *RestClient) shake() error {
 	}
 	jsonStr, err := json.Marshal(shakeRequest)
 	//if err != nil {
-	//	log.WithFields(log.Fields{
+	//	client.logger.WithFields(log.Fields{
 	//		"shakeRequest": shakeRequest,
 	//		"err":          err.Error(),
 	//	}).Error("fail to marshal shakeRequest")
 	//	return err
 	//}
-	req, err := http.NewRequest(http.MethodPost, client.RestClientProperties.RestUrl+ShakeHandPath, bytes.NewBuffer(jsonStr))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.RestClientProperties.RestUrl+ShakeHandPath, bytes.NewBuffer(jsonStr))
 	if err != nil {
-		log.WithFields(log.Fields{
+		client.logger.WithFields(log.Fields{
 			"shakeRequest": shakeRequest,
 			"err":          err.Error(),
 		}).Error("fail to new shakeRequest")
-		return err
+		return "", err
 	}
 	req.Header.Add("Content-Type", "application/json;charset=utf-8")
 	resp, err := client.httpClient.Do(req)
 	if err != nil {
-		log.WithFields(log.Fields{
+		client.logger.WithFields(log.Fields{
 			"req": req,
 			"err": err.Error(),
 		}).Error("fail to get shakeResponse")
-		return err
+		return "", err
 	}
 	defer resp.Body.Close()
 
 	body, _ := ioutil.ReadAll(resp.Body)
 	baseResp := response.BaseResp{}
 	err = json.Unmarshal(body, &baseResp)
-	//if err != nil {
-	//	log.WithFields(log.Fields{
-	//		"body": string(body),
-	//		"err":  err.Error(),
-	//	}).Error("fail to unmarshal shakeResponse")
-	//	return err
-	//}
+	if err != nil {
+		client.logger.WithFields(log.Fields{
+			"body": string(body),
+			"err":  err.Error(),
+		}).Error("fail to unmarshal shakeResponse")
+		return "", err
+	}
+	if codeErr := baseResp.Err(); codeErr != nil {
+		client.logger.WithFields(log.Fields{
+			"restCode": baseResp.Code,
+			"data":     baseResp.Data,
+		}).Error("fail to shake hand")
+		return "", fmt.Errorf("fail to shake hand,err:%w", codeErr)
+	}
+	client.logger.Info("new rest token:" + baseResp.Data)
 	client.RestToken = baseResp.Data
-	log.Info("new rest token:" + client.RestToken)
-	return nil
+	return baseResp.Data, nil
 }
 
 func (client *RestClient) ChainCall(hash, bizid, requestStr string, method model.Method) (response.BaseResp, error) {
+	return client.ChainCallWithContext(context.Background(), hash, bizid, requestStr, method)
+}
+
+func (client *RestClient) ChainCallWithContext(ctx context.Context, hash, bizid, requestStr string, method model.Method) (response.BaseResp, error) {
 	if bizid == "" {
-		return response.BaseResp{}, fmt.Errorf("bizid is empty")
+		return response.BaseResp{}, response.NewValidationError("bizid is empty")
 	}
 	if method == "" {
-		return response.BaseResp{}, fmt.Errorf("method is empty")
+		return response.BaseResp{}, response.NewValidationError("method is empty")
+	}
+	if isAdminMethod(method) && !client.admin {
+		return response.BaseResp{}, response.NewValidationError(fmt.Sprintf("%v method must be called by an admin client", method))
 	}
 	param := &model.CallRestParam{}
 	param.AccessId = client.RestClientProperties.AccessId
-	param.Token = client.RestToken
 	param.Hash = hash
 	param.BizId = bizid
 	param.RequestStr = requestStr
 	param.Method = method
-	return client.retryableSendRequest(param, client.RestClientProperties.RestUrl+ChainCallPath, ChainCall)
+	return client.retryableSendRequest(ctx, param, method, IsIdempotent(method), client.RestClientProperties.RestUrl+ChainCallPath, ChainCall)
 }
 
 func (client *RestClient) ChainCallForBiz(param model.CallRestBizParam) (response.BaseResp, error) {
-	param.Token = client.RestToken
+	return client.ChainCallForBizWithContext(context.Background(), param)
+}
+
+func (client *RestClient) ChainCallForBizWithContext(ctx context.Context, param model.CallRestBizParam) (response.BaseResp, error) {
+	if isAdminMethod(param.Method) && !client.admin {
+		return response.BaseResp{}, response.NewValidationError(fmt.Sprintf("%v method must be called by an admin client", param.Method))
+	}
+	token, err := client.tokens.Token(ctx)
+	if err != nil {
+		return response.BaseResp{}, err
+	}
+	param.Token = token
 	baseResp := utils.CheckCallRestBizParams(param)
 	if !baseResp.Success {
-		return response.BaseResp{}, fmt.Errorf("%v", baseResp.Data)
+		return response.BaseResp{}, response.NewValidationError(baseResp.Data)
 	}
 	if param.Method == model.CREATEACCOUNT || param.Method == model.DEPLOYNATIVECONTRACT || param.Method == model.QUERYACCOUNT {
 		if param.MykmsKeyId == "" {
-			return client.ChainCall("", param.BizId, param.RequestStr, param.Method)
+			return client.ChainCallWithContext(ctx, "", param.BizId, param.RequestStr, param.Method)
 		}
 	}
 
-	return client.retryableSendRequest(param, client.RestClientProperties.RestUrl+ChainCallForBizPath, ChainCallForBiz)
+	idempotent := IsIdempotent(param.Method) || param.IsLocalTransaction
+	return client.retryableSendRequest(ctx, &param, param.Method, idempotent, client.RestClientProperties.RestUrl+ChainCallForBizPath, ChainCallForBiz)
 }
 
-func (client *RestClient) retryableSendRequest(param interface{}, url string, chainCallType string) (response.BaseResp, error) {
-	retryMaxAttempts := DefaultRetryMaxAttempts
-	if client.RestClientProperties.RetryMaxAttempts != 0 {
-		retryMaxAttempts = client.RestClientProperties.RetryMaxAttempts
+// chainCallForBizResult sends param and parses the Data of a successful response into v, Data is
+// ignored when v is nil.
+func (client *RestClient) chainCallForBizResult(ctx context.Context, param model.CallRestBizParam, v interface{}) error {
+	baseResp, err := client.ChainCallForBizWithContext(ctx, param)
+	if err != nil {
+		return err
+	}
+	if !baseResp.Success || baseResp.Code != model.ServiceSuccess {
+		return fmt.Errorf("%v failed,err:%w", param.Method, response.NewCodeError(baseResp.Code, baseResp.Data, 0))
 	}
-	backoffPeriod := DefaultBackOffPeriod
-	if client.RestClientProperties.BackOffPeriod != 0 {
-		backoffPeriod = client.RestClientProperties.BackOffPeriod
+	if v == nil {
+		return nil
 	}
+	if err := json.Unmarshal([]byte(baseResp.Data), v); err != nil {
+		return fmt.Errorf("%v returned an invalid result,err:%w", param.Method, err)
+	}
+	return nil
+}
+
+// tokenStamper is implemented by every param embedding model.BaseParam.
+type tokenStamper interface {
+	SetToken(token string)
+}
 
-	tick := time.Tick(time.Duration(backoffPeriod) * time.Millisecond)
-	for i := 0; i < retryMaxAttempts; i++ {
-		jsonStr, err := json.Marshal(&param)
+// retryableSendRequest posts param to url, stamping the current token on every attempt.
+func (client *RestClient) retryableSendRequest(ctx context.Context, param tokenStamper, method model.Method, idempotent bool, url string, chainCallType string) (response.BaseResp, error) {
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return response.BaseResp{}, err
+		}
+		token, err := client.tokens.Token(ctx)
 		if err != nil {
 			return response.BaseResp{}, err
 		}
-		req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonStr))
+		param.SetToken(token)
+		jsonStr, err := json.Marshal(param)
 		if err != nil {
-			log.WithFields(log.Fields{
-				"req": req,
+			return response.BaseResp{}, err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonStr))
+		if err != nil {
+			client.logger.WithFields(log.Fields{
+				"url": url,
 				"err": err.Error(),
 			}).Errorf("fail to new %v request", chainCallType)
 			return response.BaseResp{}, err
 		}
 		req.Header.Add("Content-Type", "application/json;charset=utf-8")
+
+		outcome := RetryOutcome{Method: method, Idempotent: idempotent}
 		resp, err := client.httpClient.Do(req)
 		if err != nil {
-			log.WithFields(log.Fields{
-				"req": req,
+			client.logger.WithFields(log.Fields{
+				"url": url,
 				"err": err.Error(),
 			}).Errorf("fail to get %v response", chainCallType)
-			// retry later An error is returned if caused by client policy (such as
-			// CheckRedirect), or failure to speak HTTP (such as a network
-			// connectivity problem). A non-2xx status code doesn't cause an
-			// error.
-			select {
-			case <-tick:
-				log.WithFields(log.Fields{
-					"req": req,
-				}).Infof("retry %v request", chainCallType)
+			if ctx.Err() != nil {
+				return response.BaseResp{}, ctx.Err()
 			}
-			resp.Body.Close()
+			// An error is returned if caused by client policy (such as CheckRedirect), or failure to
+			// speak HTTP (such as a network connectivity problem). A non-2xx status code doesn't cause
+			// an error.
+			outcome.Err = err
+			lastErr = err
 		} else {
+			body, readErr := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
 			if resp.StatusCode >= 300 && resp.StatusCode < 600 {
-				log.WithFields(log.Fields{
-					"req":        req,
+				client.logger.WithFields(log.Fields{
+					"url":        url,
 					"statusCode": resp.StatusCode,
 				}).Warnf("%v return non 2xx code", chainCallType)
-				resp.Body.Close()
-				return response.BaseResp{}, fmt.Errorf("%v return non 2xx code,statusCode:%v", chainCallType, resp.StatusCode)
+				outcome.StatusCode = resp.StatusCode
+				outcome.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), client.clock.Now())
+				lastErr = fmt.Errorf("%v return non 2xx code,err:%w", chainCallType, response.NewStatusError(resp.StatusCode, string(body)))
+			} else if readErr != nil {
+				outcome.Err = readErr
+				lastErr = readErr
 			} else {
-				body, _ := ioutil.ReadAll(resp.Body)
 				baseResp := response.BaseResp{}
 				err = json.Unmarshal(body, &baseResp)
-				//if err != nil {
-				//	log.WithFields(log.Fields{
-				//		"body": string(body),
-				//		"err":  err.Error(),
-				//	}).Errorf("fail to unmarshal %v", chainCallType)
-				//	resp.Body.Close()
-				//	return response.BaseResp{}, fmt.Errorf("fail to unmarshal %v,err:%+v", chainCallType, err)
-				//}
-				log.WithFields(log.Fields{
+				if err != nil {
+					client.logger.WithFields(log.Fields{
+						"body": string(body),
+						"err":  err.Error(),
+					}).Errorf("fail to unmarshal %v", chainCallType)
+					return response.BaseResp{}, fmt.Errorf("fail to unmarshal %v,err:%w", chainCallType, err)
+				}
+				client.logger.WithFields(log.Fields{
 					"param": param,
 					"resp":  baseResp,
 				}).Info("request and resp")
-				if !baseResp.Success {
-					if baseResp.Code == "202" {
-						client.shake()
-						switch param.(type) {
-						case model.CallRestParam:
-							newParam := param.(model.CallRestParam)
-							newParam.Token = client.RestToken
-							param = newParam
-						case model.CallRestBizParam:
-							newParam := param.(model.CallRestBizParam)
-							newParam.Token = client.RestToken
-							param = newParam
-						}
-					}
-					if baseResp.Code == "202" || strings.HasPrefix(baseResp.Code, "5") {
-						log.WithFields(log.Fields{
-							"restCode": baseResp.Code,
-						}).Warnf("fail to get %v successfully", chainCallType)
-						resp.Body.Close()
-						continue // retry next time
+				codeErr := baseResp.Err()
+				if codeErr == nil || !(errors.Is(codeErr, response.ErrTokenExpired) || errors.Is(codeErr, response.ErrServer)) {
+					return baseResp, nil
+				}
+				client.logger.WithFields(log.Fields{
+					"restCode": baseResp.Code,
+				}).Warnf("fail to get %v successfully", chainCallType)
+				if errors.Is(codeErr, response.ErrTokenExpired) {
+					if _, err := client.tokens.Refresh(ctx, token); err != nil {
+						client.logger.WithFields(log.Fields{
+							"err": err.Error(),
+						}).Warn("fail to refresh rest token")
 					}
 				}
-				resp.Body.Close()
-				return baseResp, nil
+				outcome.Code = baseResp.Code
+				lastErr = codeErr
 			}
 		}
+
+		backoff, retry := client.retryPolicy.Backoff(attempt, outcome)
+		if !retry {
+			break
+		}
+		client.logger.WithFields(log.Fields{
+			"url":     url,
+			"attempt": attempt,
+			"backoff": backoff.String(),
+		}).Infof("retry %v request", chainCallType)
+		if err := sleepWithContext(ctx, backoff); err != nil {
+			return response.BaseResp{}, err
+		}
+	}
+	return response.BaseResp{}, fmt.Errorf("fail to get %v response,err:%w", chainCallType, lastErr)
+}
+
+// sleepWithContext waits for d to elapse, returning early with ctx.Err() if ctx is done first.
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
 	}
-	return response.BaseResp{}, fmt.Errorf("fail to get %v response", chainCallType)
 }
 
 func (client *RestClient) DepositSyncWithTransaction(bizid, orderId, account, tenantId, content, mykmsKeyId string, gas int64) (response.BaseResp, error) {
-	baseResp, err := client.Deposit(bizid, orderId, account, tenantId, content, mykmsKeyId, gas)
+	return client.DepositSyncWithTransactionWithContext(context.Background(), bizid, orderId, account, tenantId, content, mykmsKeyId, gas)
+}
+
+func (client *RestClient) DepositSyncWithTransactionWithContext(ctx context.Context, bizid, orderId, account, tenantId, content, mykmsKeyId string, gas int64) (response.BaseResp, error) {
+	baseResp, err := client.DepositWithContext(ctx, bizid, orderId, account, tenantId, content, mykmsKeyId, gas)
 	if err != nil {
 		return response.BaseResp{}, err
 	}
-	if !baseResp.Success || baseResp.Code != "200" {
-		return response.BaseResp{}, fmt.Errorf("deposit failed,code:%+v err msg:%+v", baseResp.Code, baseResp.Data)
-	}
-	return client.MultipleQueryTransaction(bizid, baseResp.Data)
-}
-
-//func (client *RestClient) CallSolcContractSyncWithReceipt(abi abi.ABI, bizid, orderId, account, tenantId, kmsId, contractName, methodSignature, inputParamListStr, outTypes string, gas int64, respStruct interface{}) (response.BaseResp, error) {
-//	callRestBizParam := model.CallRestBizParam{
-//		BaseParam: model.BaseParam{
-//			AccessId: client.RestClientProperties.AccessId,
-//			BizId:    bizid,
-//			Method:   model.CALLCONTRACTBIZASYNC,
-//		},
-//		OrderId:           orderId,
-//		Account:           account,
-//		TenantId:          tenantId,
-//		ContractName:      contractName,
-//		MethodSignature:   methodSignature,
-//		InputParamListStr: inputParamListStr,
-//		OutTypes:          outTypes,
-//		MykmsKeyId:        kmsId,
-//		Gas:               gas, // 0表示不受限
-//	}
-//	callResp, err := client.ChainCallForBiz(callRestBizParam)
-//	if err != nil {
-//		return response.BaseResp{}, err
-//	}
-//	if callResp.Success && callResp.Code == "200" {
-//		baseResp, err := client.MultipleQueryReceipt(bizid, callResp.Data)
-//		if err != nil {
-//			return response.BaseResp{}, err
-//		}
-//		transactionReceipt := mychain.GetDefaultTransactionReceipt()
-//		err = json.Unmarshal([]byte(baseResp.Data), &transactionReceipt)
-//		if err != nil {
-//			return response.BaseResp{}, err
-//		}
-//		output := make([]string, 0)
-//		err = json.Unmarshal([]byte(outTypes), &output)
-//		if err != nil {
-//			return response.BaseResp{}, err
-//		}
-//		if len(output) > 0 {
-//			if transactionReceipt.Output == "" && output[0] != model.VOID {
-//				return response.BaseResp{}, fmt.Errorf("function has no any output")
-//			}
-//			decodedOutput, err := base64.StdEncoding.DecodeString(transactionReceipt.Output)
-//			if err != nil {
-//				return response.BaseResp{}, err
-//			}
-//			strParts := strings.Split(methodSignature, "(")
-//			methodName := strParts[0]
-//			err = abi.Unpack(respStruct, methodName, decodedOutput)
-//			if err != nil {
-//				return response.BaseResp{}, err
-//			}
-//			jsonStr, err := json.Marshal(respStruct)
-//			if err != nil {
-//				return response.BaseResp{}, err
-//			}
-//			return response.BaseResp{Success: true, Code: "200", Data: string(jsonStr)}, nil
-//		}
-//	}
-//	return response.BaseResp{}, fmt.Errorf("no succ call contract resp,Success:%v Code:%v", callResp.Success, callResp.Code)
-//}
+	if !baseResp.Success || baseResp.Code != model.ServiceSuccess {
+		return response.BaseResp{}, fmt.Errorf("deposit failed,err:%w", response.NewCodeError(baseResp.Code, baseResp.Data, 0))
+	}
+	return client.MultipleQueryTransactionWithContext(ctx, bizid, baseResp.Data)
+}
+
+func (client *RestClient) CallContractSyncWithReceipt(contractAbi abi.ABI, bizid, orderId, account, tenantId, kmsId, contractName, methodSignature, inputParamListStr, outTypes string, gas int64, respStruct interface{}) (response.BaseResp, error) {
+	return client.CallContractSyncWithReceiptWithContext(context.Background(), contractAbi, bizid, orderId, account, tenantId, kmsId, contractName, methodSignature, inputParamListStr, outTypes, gas, respStruct)
+}
+
+// CallContractSyncWithReceiptWithContext calls the contract asynchronously, waits for the receipt and
+// decodes its output into respStruct with contractAbi. Empty outTypes are taken from the abi. A receipt
+// with a non zero result returns an error wrapping response.ErrTxFailed. On success Data holds
+// respStruct as json.
+func (client *RestClient) CallContractSyncWithReceiptWithContext(ctx context.Context, contractAbi abi.ABI, bizid, orderId, account, tenantId, kmsId, contractName, methodSignature, inputParamListStr, outTypes string, gas int64, respStruct interface{}) (response.BaseResp, error) {
+	method, err := contractAbi.Method(methodSignature)
+	if err != nil {
+		return response.BaseResp{}, err
+	}
+	if outTypes == "" {
+		outTypes = method.OutTypes()
+	}
+	if inputParamListStr == "" {
+		inputParamListStr = "[]"
+	}
+	callRestBizParam := model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId: client.RestClientProperties.AccessId,
+			BizId:    bizid,
+			Method:   model.CALLCONTRACTBIZASYNC,
+		},
+		OrderId:           orderId,
+		Account:           account,
+		TenantId:          tenantId,
+		ContractName:      contractName,
+		MethodSignature:   methodSignature,
+		InputParamListStr: inputParamListStr,
+		OutTypes:          outTypes,
+		MykmsKeyId:        kmsId,
+		Gas:               gas, // 0表示不受限
+	}
+	callResp, err := client.ChainCallForBizWithContext(ctx, callRestBizParam)
+	if err != nil {
+		return response.BaseResp{}, err
+	}
+	if !callResp.Success || callResp.Code != model.ServiceSuccess {
+		return response.BaseResp{}, fmt.Errorf("call contract failed,err:%w", response.NewCodeError(callResp.Code, callResp.Data, 0))
+	}
+	result, err := client.WaitForReceiptWithContext(ctx, bizid, callResp.Data, WaitOptions{})
+	if err != nil {
+		return response.BaseResp{}, err
+	}
+	if result.State != WaitStateDone {
+		return response.BaseResp{}, fmt.Errorf("query receipt failed,hash:%v err:%w", callResp.Data, result.Resp.Err())
+	}
+	transactionReceipt := mychain.TransactionReceipt{}
+	err = json.Unmarshal([]byte(result.Resp.Data), &transactionReceipt)
+	if err != nil {
+		return response.BaseResp{}, err
+	}
+	if transactionReceipt.Result != 0 {
+		return response.BaseResp{}, fmt.Errorf("%w,hash:%v result:%v", response.ErrTxFailed, callResp.Data, transactionReceipt.Result)
+	}
+	if len(method.Outputs) > 0 {
+		if transactionReceipt.Output == "" {
+			return response.BaseResp{}, fmt.Errorf("function has no any output,hash:%v", callResp.Data)
+		}
+		decodedOutput, err := base64.StdEncoding.DecodeString(transactionReceipt.Output)
+		if err != nil {
+			return response.BaseResp{}, err
+		}
+		err = contractAbi.Unpack(respStruct, method.Sig(), decodedOutput)
+		if err != nil {
+			return response.BaseResp{}, err
+		}
+	}
+	jsonStr, err := json.Marshal(respStruct)
+	if err != nil {
+		return response.BaseResp{}, err
+	}
+	return response.BaseResp{Success: true, Code: model.ServiceSuccess, Data: string(jsonStr)}, nil
+}
 
 func (client *RestClient) QueryAccount(bizid, account string) (response.BaseResp, error) {
+	return client.QueryAccountWithContext(context.Background(), bizid, account)
+}
+
+func (client *RestClient) QueryAccountWithContext(ctx context.Context, bizid, account string) (response.BaseResp, error) {
 	clientParam, err := client.CreateQueryAccountParam(account)
 	if err != nil {
 		return response.BaseResp{}, err
 	}
-	return client.ChainCall(clientParam.Hash, bizid, clientParam.SignData, model.QUERYACCOUNT)
+	return client.ChainCallWithContext(ctx, clientParam.Hash, bizid, clientParam.SignData, model.QUERYACCOUNT)
 }
 
 func (client *RestClient) CreateAccountWithKmsId(bizid, orderId, account, tenantId, kmsId string) (response.BaseResp, error) {
+	return client.CreateAccountWithKmsIdWithContext(context.Background(), bizid, orderId, account, tenantId, kmsId)
+}
+
+func (client *RestClient) CreateAccountWithKmsIdWithContext(ctx context.Context, bizid, orderId, account, tenantId, kmsId string) (response.BaseResp, error) {
 	callRestBizParam := model.CallRestBizParam{
 		BaseParam: model.BaseParam{
 			AccessId: client.RestClientProperties.AccessId,
@@ -386,10 +555,14 @@ func (client *RestClient) CreateAccountWithKmsId(bizid, orderId, account, tenant
 		Account:    account,
 		MykmsKeyId: kmsId,
 	}
-	return client.ChainCallForBiz(callRestBizParam)
+	return client.ChainCallForBizWithContext(ctx, callRestBizParam)
 }
 
 func (client *RestClient) CallContract(bizid, orderId, account, tenantId, contractName, methodSignature, inputParamListStr, outTypes, kmsId string, isLocal bool, gas int64) (response.BaseResp, error) {
+	return client.CallContractWithContext(context.Background(), bizid, orderId, account, tenantId, contractName, methodSignature, inputParamListStr, outTypes, kmsId, isLocal, gas)
+}
+
+func (client *RestClient) CallContractWithContext(ctx context.Context, bizid, orderId, account, tenantId, contractName, methodSignature, inputParamListStr, outTypes, kmsId string, isLocal bool, gas int64) (response.BaseResp, error) {
 	callRestBizParam := model.CallRestBizParam{
 		BaseParam: model.BaseParam{
 			AccessId: client.RestClientProperties.AccessId,
@@ -407,10 +580,14 @@ func (client *RestClient) CallContract(bizid, orderId, account, tenantId, contra
 		IsLocalTransaction: isLocal,
 		Gas:                gas, // 0表示不受限
 	}
-	return client.ChainCallForBiz(callRestBizParam)
+	return client.ChainCallForBizWithContext(ctx, callRestBizParam)
 }
 
 func (client *RestClient) DeployContract(bizid, orderId, account, tenantId, kmsId, contractName, contractCode string, gas int64) (response.BaseResp, error) {
+	return client.DeployContractWithContext(context.Background(), bizid, orderId, account, tenantId, kmsId, contractName, contractCode, gas)
+}
+
+func (client *RestClient) DeployContractWithContext(ctx context.Context, bizid, orderId, account, tenantId, kmsId, contractName, contractCode string, gas int64) (response.BaseResp, error) {
 	//deploy contract
 	callRestBizParam := model.CallRestBizParam{
 		BaseParam: model.BaseParam{
@@ -426,10 +603,271 @@ func (client *RestClient) DeployContract(bizid, orderId, account, tenantId, kmsI
 		ContractCode: contractCode,
 		Gas:          gas, // 0表示不受限
 	}
-	return client.ChainCallForBiz(callRestBizParam)
+	return client.ChainCallForBizWithContext(ctx, callRestBizParam)
+}
+
+func (client *RestClient) DeployWasmContract(bizid, orderId, account, tenantId, kmsId, contractName, contractCode string, gas int64, methodSignatures ...string) (response.BaseResp, error) {
+	return client.DeployWasmContractWithContext(context.Background(), bizid, orderId, account, tenantId, kmsId, contractName, contractCode, gas, methodSignatures...)
+}
+
+// DeployWasmContractWithContext parses and verifies the hex encoded contractCode before it is sent, and
+// checks that every one of methodSignatures can be called on it.
+func (client *RestClient) DeployWasmContractWithContext(ctx context.Context, bizid, orderId, account, tenantId, kmsId, contractName, contractCode string, gas int64, methodSignatures ...string) (response.BaseResp, error) {
+	module, err := wasm.ParseContractCode(contractCode)
+	if err != nil {
+		return response.BaseResp{}, response.NewValidationError(err.Error())
+	}
+	for _, methodSignature := range methodSignatures {
+		if err := module.CheckMethod(methodSignature); err != nil {
+			return response.BaseResp{}, response.NewValidationError(err.Error())
+		}
+	}
+	callRestBizParam := model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId: client.RestClientProperties.AccessId,
+			BizId:    bizid,
+			Method:   model.DEPLOYWASMCONTRACT,
+		},
+		OrderId:      orderId,
+		Account:      account,
+		MykmsKeyId:   kmsId,
+		TenantId:     tenantId,
+		ContractName: contractName,
+		ContractCode: contractCode,
+		Gas:          gas, // 0表示不受限
+		VmTypeEnum:   model.WASM,
+	}
+	return client.ChainCallForBizWithContext(ctx, callRestBizParam)
+}
+
+func (client *RestClient) CallWasmContract(bizid, orderId, account, tenantId, contractName, methodSignature, inputParamListStr, outTypes, kmsId string, isLocal bool, gas int64) (response.BaseResp, error) {
+	return client.CallWasmContractWithContext(context.Background(), bizid, orderId, account, tenantId, contractName, methodSignature, inputParamListStr, outTypes, kmsId, isLocal, gas)
+}
+
+func (client *RestClient) CallWasmContractWithContext(ctx context.Context, bizid, orderId, account, tenantId, contractName, methodSignature, inputParamListStr, outTypes, kmsId string, isLocal bool, gas int64) (response.BaseResp, error) {
+	return client.callWasmContract(ctx, model.CALLWASMCONTRACT, bizid, orderId, account, tenantId, contractName, methodSignature, inputParamListStr, outTypes, kmsId, isLocal, gas)
+}
+
+func (client *RestClient) CallWasmContractAsync(bizid, orderId, account, tenantId, contractName, methodSignature, inputParamListStr, outTypes, kmsId string, gas int64) (response.BaseResp, error) {
+	return client.CallWasmContractAsyncWithContext(context.Background(), bizid, orderId, account, tenantId, contractName, methodSignature, inputParamListStr, outTypes, kmsId, gas)
+}
+
+// CallWasmContractAsyncWithContext returns the transaction hash in Data, use WaitForReceipt for the result.
+func (client *RestClient) CallWasmContractAsyncWithContext(ctx context.Context, bizid, orderId, account, tenantId, contractName, methodSignature, inputParamListStr, outTypes, kmsId string, gas int64) (response.BaseResp, error) {
+	return client.callWasmContract(ctx, model.CALLWASMCONTRACTASYNC, bizid, orderId, account, tenantId, contractName, methodSignature, inputParamListStr, outTypes, kmsId, false, gas)
+}
+
+func (client *RestClient) callWasmContract(ctx context.Context, method model.Method, bizid, orderId, account, tenantId, contractName, methodSignature, inputParamListStr, outTypes, kmsId string, isLocal bool, gas int64) (response.BaseResp, error) {
+	callRestBizParam := model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId: client.RestClientProperties.AccessId,
+			BizId:    bizid,
+			Method:   method,
+		},
+		OrderId:            orderId,
+		Account:            account,
+		TenantId:           tenantId,
+		ContractName:       contractName,
+		MethodSignature:    methodSignature,
+		InputParamListStr:  inputParamListStr,
+		OutTypes:           outTypes,
+		MykmsKeyId:         kmsId,
+		IsLocalTransaction: isLocal,
+		Gas:                gas, // 0表示不受限
+		VmTypeEnum:         model.WASM,
+	}
+	return client.ChainCallForBizWithContext(ctx, callRestBizParam)
+}
+
+// DeployNativeContract deploys a native contract signed with the kms key kmsId, a native contract deployed
+// with a key held by the caller is sent as a signed requestStr through ChainCall.
+func (client *RestClient) DeployNativeContract(bizid, orderId, account, tenantId, kmsId, contractName, contractCode string, gas int64) (response.BaseResp, error) {
+	return client.DeployNativeContractWithContext(context.Background(), bizid, orderId, account, tenantId, kmsId, contractName, contractCode, gas)
+}
+
+func (client *RestClient) DeployNativeContractWithContext(ctx context.Context, bizid, orderId, account, tenantId, kmsId, contractName, contractCode string, gas int64) (response.BaseResp, error) {
+	if kmsId == "" {
+		return response.BaseResp{}, response.NewValidationError(fmt.Sprintf("%v method must has mykmsKeyId", model.DEPLOYNATIVECONTRACT))
+	}
+	callRestBizParam := model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId: client.RestClientProperties.AccessId,
+			BizId:    bizid,
+			Method:   model.DEPLOYNATIVECONTRACT,
+		},
+		OrderId:      orderId,
+		Account:      account,
+		MykmsKeyId:   kmsId,
+		TenantId:     tenantId,
+		ContractName: contractName,
+		ContractCode: contractCode,
+		Gas:          gas, // 0表示不受限
+		VmTypeEnum:   model.NATIVE,
+	}
+	return client.ChainCallForBizWithContext(ctx, callRestBizParam)
+}
+
+// UpdateContractOptions controls UpdateContract.
+type UpdateContractOptions struct {
+	// VmType is the vm of the contract, model.EVM when empty.
+	VmType model.VMTypeEnum
+	// RegisteredAbi and Abi are the abi of the registered and of the new solidity code, when both are
+	// set the update is refused if it breaks callers of the registered abi.
+	RegisteredAbi *abi.ABI
+	Abi           *abi.ABI
+	// RegisteredCode is the hex code of the registered wasm contract, when set the update is refused if
+	// it removes or changes an export.
+	RegisteredCode string
+	// NonBiz sends UPDATECONTRACT instead of UPDATECONTRACTFORBIZ.
+	NonBiz bool
+	// Wait waits for the receipt of the update, polling with WaitOptions.
+	Wait        bool
+	WaitOptions WaitOptions
+}
+
+func (client *RestClient) UpdateContract(bizid, orderId, account, tenantId, kmsId, contractName, contractCode string, gas int64, opts UpdateContractOptions) (response.BaseResp, error) {
+	return client.UpdateContractWithContext(context.Background(), bizid, orderId, account, tenantId, kmsId, contractName, contractCode, gas, opts)
+}
+
+// UpdateContractWithContext replaces the code of contractName with contractCode. A breaking change
+// found by abi.CheckUpgrade or wasm.CheckUpgrade, and wasm code that does not verify, return an error
+// wrapping response.ErrValidation before anything is sent. Data holds the transaction hash, or the
+// receipt when opts.Wait is set, in which case a receipt with a non zero result returns an error
+// wrapping response.ErrTxFailed.
+func (client *RestClient) UpdateContractWithContext(ctx context.Context, bizid, orderId, account, tenantId, kmsId, contractName, contractCode string, gas int64, opts UpdateContractOptions) (response.BaseResp, error) {
+	vmType := opts.VmType
+	if vmType == "" {
+		vmType = model.EVM
+	}
+	switch vmType {
+	case model.WASM:
+		module, err := wasm.ParseContractCode(contractCode)
+		if err != nil {
+			return response.BaseResp{}, response.NewValidationError(err.Error())
+		}
+		if opts.RegisteredCode != "" {
+			registered, err := wasm.ParseContractCode(opts.RegisteredCode)
+			if err != nil {
+				return response.BaseResp{}, response.NewValidationError(err.Error())
+			}
+			if err := wasm.CheckUpgrade(registered, module); err != nil {
+				return response.BaseResp{}, response.NewValidationError(err.Error())
+			}
+		}
+	default:
+		if opts.RegisteredAbi != nil && opts.Abi != nil {
+			if err := abi.CheckUpgrade(*opts.RegisteredAbi, *opts.Abi); err != nil {
+				return response.BaseResp{}, response.NewValidationError(err.Error())
+			}
+		}
+	}
+	method := model.Method(model.UPDATECONTRACTFORBIZ)
+	if opts.NonBiz {
+		method = model.UPDATECONTRACT
+	}
+	callRestBizParam := model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId: client.RestClientProperties.AccessId,
+			BizId:    bizid,
+			Method:   method,
+		},
+		OrderId:      orderId,
+		Account:      account,
+		MykmsKeyId:   kmsId,
+		TenantId:     tenantId,
+		ContractName: contractName,
+		ContractCode: contractCode,
+		Gas:          gas, // 0表示不受限
+		VmTypeEnum:   vmType,
+	}
+	baseResp, err := client.ChainCallForBizWithContext(ctx, callRestBizParam)
+	if err != nil || !opts.Wait {
+		return baseResp, err
+	}
+	if !baseResp.Success || baseResp.Code != model.ServiceSuccess {
+		return baseResp, fmt.Errorf("update contract failed,err:%w", response.NewCodeError(baseResp.Code, baseResp.Data, 0))
+	}
+	result, err := client.WaitForReceiptWithContext(ctx, bizid, baseResp.Data, opts.WaitOptions)
+	if err != nil {
+		return response.BaseResp{}, err
+	}
+	if result.State != WaitStateDone {
+		return result.Resp, fmt.Errorf("query receipt failed,hash:%v err:%w", baseResp.Data, result.Resp.Err())
+	}
+	transactionReceipt := mychain.TransactionReceipt{}
+	if err := json.Unmarshal([]byte(result.Resp.Data), &transactionReceipt); err != nil {
+		return response.BaseResp{}, err
+	}
+	if transactionReceipt.Result != 0 {
+		return result.Resp, fmt.Errorf("%w,hash:%v result:%v", response.ErrTxFailed, baseResp.Data, transactionReceipt.Result)
+	}
+	return result.Resp, nil
+}
+
+func (client *RestClient) CallNativeContract(bizid, orderId, account, tenantId, contractName, methodSignature, nativeContractData, kmsId string, gas int64) (response.BaseResp, error) {
+	return client.CallNativeContractWithContext(context.Background(), bizid, orderId, account, tenantId, contractName, methodSignature, nativeContractData, kmsId, gas)
+}
+
+// CallNativeContractWithContext calls methodSignature of the native contract contractName with the
+// encoded nativeContractData and waits for the transaction to be executed.
+func (client *RestClient) CallNativeContractWithContext(ctx context.Context, bizid, orderId, account, tenantId, contractName, methodSignature, nativeContractData, kmsId string, gas int64) (response.BaseResp, error) {
+	return client.callNativeContract(ctx, model.CALLNATIVECONTRACTFORBIZ, bizid, orderId, account, tenantId, contractName, methodSignature, nativeContractData, kmsId, gas)
+}
+
+func (client *RestClient) CallNativeContractAsync(bizid, orderId, account, tenantId, contractName, methodSignature, nativeContractData, kmsId string, gas int64) (response.BaseResp, error) {
+	return client.CallNativeContractAsyncWithContext(context.Background(), bizid, orderId, account, tenantId, contractName, methodSignature, nativeContractData, kmsId, gas)
+}
+
+// CallNativeContractAsyncWithContext returns the transaction hash in Data, use WaitForReceipt for the result.
+func (client *RestClient) CallNativeContractAsyncWithContext(ctx context.Context, bizid, orderId, account, tenantId, contractName, methodSignature, nativeContractData, kmsId string, gas int64) (response.BaseResp, error) {
+	return client.callNativeContract(ctx, model.CALLNATIVECONTRACTFORBIZASYNC, bizid, orderId, account, tenantId, contractName, methodSignature, nativeContractData, kmsId, gas)
+}
+
+func (client *RestClient) CallNativeContractNonBiz(bizid, orderId, account, tenantId, contractName, methodSignature, nativeContractData, kmsId string, gas int64) (response.BaseResp, error) {
+	return client.CallNativeContractNonBizWithContext(context.Background(), bizid, orderId, account, tenantId, contractName, methodSignature, nativeContractData, kmsId, gas)
+}
+
+// CallNativeContractNonBizWithContext is CallNativeContractWithContext sent as CALLNATIVECONTRACT
+// instead of CALLNATIVECONTRACTFORBIZ.
+func (client *RestClient) CallNativeContractNonBizWithContext(ctx context.Context, bizid, orderId, account, tenantId, contractName, methodSignature, nativeContractData, kmsId string, gas int64) (response.BaseResp, error) {
+	return client.callNativeContract(ctx, model.CALLNATIVECONTRACT, bizid, orderId, account, tenantId, contractName, methodSignature, nativeContractData, kmsId, gas)
+}
+
+func (client *RestClient) CallNativeContractNonBizAsync(bizid, orderId, account, tenantId, contractName, methodSignature, nativeContractData, kmsId string, gas int64) (response.BaseResp, error) {
+	return client.CallNativeContractNonBizAsyncWithContext(context.Background(), bizid, orderId, account, tenantId, contractName, methodSignature, nativeContractData, kmsId, gas)
+}
+
+// CallNativeContractNonBizAsyncWithContext is CallNativeContractAsyncWithContext sent as
+// CALLNATIVECONTRACTASYNC instead of CALLNATIVECONTRACTFORBIZASYNC.
+func (client *RestClient) CallNativeContractNonBizAsyncWithContext(ctx context.Context, bizid, orderId, account, tenantId, contractName, methodSignature, nativeContractData, kmsId string, gas int64) (response.BaseResp, error) {
+	return client.callNativeContract(ctx, model.CALLNATIVECONTRACTASYNC, bizid, orderId, account, tenantId, contractName, methodSignature, nativeContractData, kmsId, gas)
+}
+
+func (client *RestClient) callNativeContract(ctx context.Context, method model.Method, bizid, orderId, account, tenantId, contractName, methodSignature, nativeContractData, kmsId string, gas int64) (response.BaseResp, error) {
+	callRestBizParam := model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId: client.RestClientProperties.AccessId,
+			BizId:    bizid,
+			Method:   method,
+		},
+		OrderId:            orderId,
+		Account:            account,
+		TenantId:           tenantId,
+		ContractName:       contractName,
+		MethodSignature:    methodSignature,
+		NativeContractData: nativeContractData,
+		MykmsKeyId:         kmsId,
+		Gas:                gas, // 0表示不受限
+		VmTypeEnum:         model.NATIVE,
+	}
+	return client.ChainCallForBizWithContext(ctx, callRestBizParam)
 }
 
 func (client *RestClient) Deposit(bizid, orderId, account, tenantId, content, mykmsKeyId string, gas int64) (response.BaseResp, error) {
+	return client.DepositWithContext(context.Background(), bizid, orderId, account, tenantId, content, mykmsKeyId, gas)
+}
+
+func (client *RestClient) DepositWithContext(ctx context.Context, bizid, orderId, account, tenantId, content, mykmsKeyId string, gas int64) (response.BaseResp, error) {
 	callRestBizParam := model.CallRestBizParam{
 		BaseParam: model.BaseParam{
 			AccessId: client.RestClientProperties.AccessId,
@@ -443,10 +881,16 @@ func (client *RestClient) Deposit(bizid, orderId, account, tenantId, content, my
 		TenantId:   tenantId,
 		Gas:        gas, // 0表示不受限
 	}
-	return client.ChainCallForBiz(callRestBizParam)
+	return client.ChainCallForBizWithContext(ctx, callRestBizParam)
 }
 
-func (client *RestClient) QueryReceipt(bizid, hash string) (response.BaseResp, error) {
+func (client *RestClient) QueryReceipt(bizid, hash string) (mychain.TransactionReceipt, error) {
+	return client.QueryReceiptWithContext(context.Background(), bizid, hash)
+}
+
+// QueryReceiptWithContext returns the parsed receipt of the transaction hash, non success codes are
+// returned as errors, a receipt not yet queryable wraps response.ErrNotFound.
+func (client *RestClient) QueryReceiptWithContext(ctx context.Context, bizid, hash string) (mychain.TransactionReceipt, error) {
 	callRestBizParam := model.CallRestBizParam{
 		BaseParam: model.BaseParam{
 			AccessId: client.RestClientProperties.AccessId,
@@ -455,10 +899,30 @@ func (client *RestClient) QueryReceipt(bizid, hash string) (response.BaseResp, e
 			Method:   model.QUERYRECEIPT,
 		},
 	}
-	return client.ChainCallForBiz(callRestBizParam)
+	baseResp, err := client.ChainCallForBizWithContext(ctx, callRestBizParam)
+	if err != nil {
+		return mychain.TransactionReceipt{}, err
+	}
+	if !baseResp.Success || baseResp.Code != model.ServiceSuccess {
+		return mychain.TransactionReceipt{}, fmt.Errorf("query receipt failed,hash:%v err:%w", hash, response.NewCodeError(baseResp.Code, baseResp.Data, 0))
+	}
+	receipt := mychain.TransactionReceipt{}
+	if err := json.Unmarshal([]byte(baseResp.Data), &receipt); err != nil {
+		return mychain.TransactionReceipt{}, fmt.Errorf("query receipt returned an invalid receipt,hash:%v err:%w", hash, err)
+	}
+	if receipt.Hash == "" {
+		receipt.Hash = hash
+	}
+	return receipt, nil
+}
+
+func (client *RestClient) QueryTransaction(bizid, hash string) (mychain.Transaction, error) {
+	return client.QueryTransactionWithContext(context.Background(), bizid, hash)
 }
 
-func (client *RestClient) QueryTransaction(bizid, hash string) (response.BaseResp, error) {
+// QueryTransactionWithContext returns the parsed transaction hash with its block number, non success
+// codes are returned as errors.
+func (client *RestClient) QueryTransactionWithContext(ctx context.Context, bizid, hash string) (mychain.Transaction, error) {
 	callRestBizParam := model.CallRestBizParam{
 		BaseParam: model.BaseParam{
 			AccessId: client.RestClientProperties.AccessId,
@@ -467,39 +931,44 @@ func (client *RestClient) QueryTransaction(bizid, hash string) (response.BaseRes
 			Method:   model.QUERYTRANSACTION,
 		},
 	}
-	return client.ChainCallForBiz(callRestBizParam)
+	baseResp, err := client.ChainCallForBizWithContext(ctx, callRestBizParam)
+	if err != nil {
+		return mychain.Transaction{}, err
+	}
+	if !baseResp.Success || baseResp.Code != model.ServiceSuccess {
+		return mychain.Transaction{}, fmt.Errorf("query transaction failed,hash:%v err:%w", hash, response.NewCodeError(baseResp.Code, baseResp.Data, 0))
+	}
+	queried := struct {
+		BlockNumber int64               `json:"blockNumber"`
+		Transaction mychain.Transaction `json:"transactionDO"`
+	}{}
+	if err := json.Unmarshal([]byte(baseResp.Data), &queried); err != nil {
+		return mychain.Transaction{}, fmt.Errorf("query transaction returned an invalid transaction,hash:%v err:%w", hash, err)
+	}
+	transaction := queried.Transaction
+	transaction.BlockNumber = queried.BlockNumber
+	if transaction.Hash == "" {
+		transaction.Hash = hash
+	}
+	return transaction, nil
 }
 
 func (client *RestClient) MultipleQueryReceipt(bizid, hash string) (response.BaseResp, error) {
-	var baseResp response.BaseResp
-	var err error
-	for i := 0; i < client.RestClientProperties.RetryMaxAttempts; i++ {
-		baseResp, err = client.ChainCall(hash, bizid, "", model.QUERYRECEIPT)
-		if err != nil {
-			return baseResp, err
-		} else if !baseResp.Success && (baseResp.Code == model.ServiceQueryNoResult ||
-			baseResp.Code == model.ServiceTxWaitingVerify ||
-			baseResp.Code == model.ServiceTxWaitingExecute) {
-			continue
-		}
-		return baseResp, err
-	}
-	return baseResp, err
+	return client.MultipleQueryReceiptWithContext(context.Background(), bizid, hash)
+}
+
+// MultipleQueryReceiptWithContext waits for the receipt with the default WaitOptions.
+func (client *RestClient) MultipleQueryReceiptWithContext(ctx context.Context, bizid, hash string) (response.BaseResp, error) {
+	result, err := client.WaitForReceiptWithContext(ctx, bizid, hash, WaitOptions{})
+	return result.Resp, err
 }
 
 func (client *RestClient) MultipleQueryTransaction(bizid, hash string) (response.BaseResp, error) {
-	var baseResp response.BaseResp
-	var err error
-	for i := 0; i < client.RestClientProperties.RetryMaxAttempts; i++ {
-		baseResp, err = client.ChainCall(hash, bizid, "", model.QUERYTRANSACTION)
-		if err != nil {
-			return baseResp, err
-		} else if !baseResp.Success && (baseResp.Code == model.ServiceQueryNoResult ||
-			baseResp.Code == model.ServiceTxWaitingVerify ||
-			baseResp.Code == model.ServiceTxWaitingExecute) {
-			continue
-		}
-		return baseResp, err
-	}
-	return baseResp, err
+	return client.MultipleQueryTransactionWithContext(context.Background(), bizid, hash)
+}
+
+// MultipleQueryTransactionWithContext waits for the transaction with the default WaitOptions.
+func (client *RestClient) MultipleQueryTransactionWithContext(ctx context.Context, bizid, hash string) (response.BaseResp, error) {
+	result, err := client.WaitForTransactionWithContext(ctx, bizid, hash, WaitOptions{})
+	return result.Resp, err
 }
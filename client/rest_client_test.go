@@ -1,12 +1,32 @@
 package client
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/abi"
+	"github.com/ctwel/antchain-client-go-sdk/client/config"
+	"github.com/ctwel/antchain-client-go-sdk/client/internal/resttest"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/mychain"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+	"github.com/ctwel/antchain-client-go-sdk/utils"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -91,13 +111,109 @@ const abiJsonStr = `[
   }
 ]`
 
-func TestNewRestClient(t *testing.T) {
-	configFilePath := os.Getenv("GOPATH") + "/src/github.com/ctwel/antchain-client-go-sdk/test/rest-config.json"
-	restClient, err := NewRestClient(configFilePath)
+// RestBizTestWasmContractCode exports add(i32,i32) i32
+const RestBizTestWasmContractCode = "0061736d0100000001070160027f7f017f030201000707010361646400000a09010700200020016a0b"
+
+// RestBizTestKeyPath and RestBizTestOtherKeyPath are throwaway access keys generated by TestMain.
+var (
+	RestBizTestKeyPath      string
+	RestBizTestOtherKeyPath string
+)
+
+const (
+	RestBizTestAccessID = "rest_biz_test_access_id"
+	// 部署合约使用的合约代码,与下面的abi对应
+	RestBizTestContractCode = "608060405234801561001057600080fd5b506040516102ef3803806102ef833981018060405281019080805190602001909291908051820192919050505081600081905550600060018190555050506102928061005d6000396000f300608060405260043610610057576000357c0100000000000000000000000000000000000000000000000000000000900463ffffffff1680631002aecd1461005c57806338af3eed146101f0578063954ab4b21461021b575b600080fd5b34801561006857600080fd5b50610109600480360381019080803590602001908201803590602001908080601f0160208091040260200160405190810160405280939291908181526020018383808284378201915050505050509192919290803590602001908201803590602001908080601f0160208091040260200160405190810160405280939291908181526020018383808284378201915050505050509192919290505050610246565b604051808060200180602001838103835285818151815260200191508051906020019080838360005b8381101561014d578082015181840152602081019050610132565b50505050905090810190601f16801561017a5780820380516001836020036101000a031916815260200191505b50838103825284818151815260200191508051906020019080838360005b838110156101b3578082015181840152602081019050610198565b50505050905090810190601f1680156101e05780820380516001836020036101000a031916815260200191505b5094505050505060405180910390f35b3480156101fc57600080fd5b50610205610256565b6040518082815260200191505060405180910390f35b34801561022757600080fd5b5061023061025c565b6040518082815260200191505060405180910390f35b6060808383915091509250929050565b60015481565b60006001549050905600a165627a7a72305820ac9ff0ce4f83f475e39f7a8ecdfeb0b16673a328ca1af858b2ce81ccbe75837c0029"
+)
+
+func TestMain(m *testing.M) {
+	dir, err := ioutil.TempDir("", "rest-test-keys")
+	if err != nil {
+		panic(err)
+	}
+	RestBizTestKeyPath, err = writeTestKey(dir, "access.key")
+	if err == nil {
+		RestBizTestOtherKeyPath, err = writeTestKey(dir, "access-other.key")
+	}
+	if err != nil {
+		os.RemoveAll(dir)
+		panic(err)
+	}
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// writeTestKey writes a new pem encoded PKCS8 RSA private key to dir/name.
+func writeTestKey(dir, name string) (string, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return "", err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
 	if err != nil {
-		t.Errorf("failed to NewRestClient err:%+v", err)
+		return "", err
 	}
-	fmt.Printf("restClient:%+v", restClient)
+	path := filepath.Join(dir, name)
+	return path, ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600)
+}
+
+// newTestServer starts a resttest server accepting RestBizTestAccessID signed with RestBizTestKeyPath.
+func newTestServer(t *testing.T, opts ...resttest.Option) *resttest.Server {
+	priv, err := ioutil.ReadFile(RestBizTestKeyPath)
+	require.Truef(t, err == nil, "fail to read test access key,err:%+v", err)
+	privateKey, err := utils.ParsePrivateKey(priv)
+	require.Truef(t, err == nil, "fail to parse test access key,err:%+v", err)
+	opts = append([]resttest.Option{resttest.WithAccessKey(RestBizTestAccessID, &privateKey.PublicKey)}, opts...)
+	server := resttest.NewServer(opts...)
+	server.CreateAccount(RestBizTestAccount, RestBizTestKmsID)
+	return server
+}
+
+// writeTestConfig writes the properties of server to a temp rest config file.
+func writeTestConfig(t *testing.T, server *resttest.Server, accessId, accessSecret string) string {
+	restClientProperties := server.Properties()
+	restClientProperties.AccessId = accessId
+	restClientProperties.AccessSecret = accessSecret
+	data, err := json.Marshal(&restClientProperties)
+	require.Truef(t, err == nil, "fail to marshal rest config,err:%+v", err)
+	file, err := ioutil.TempFile("", "rest-config-*.json")
+	require.Truef(t, err == nil, "fail to create rest config,err:%+v", err)
+	defer file.Close()
+	_, err = file.Write(data)
+	require.Truef(t, err == nil, "fail to write rest config,err:%+v", err)
+	return file.Name()
+}
+
+func newTestRestClient(t *testing.T, opts ...resttest.Option) (*resttest.Server, *RestClient) {
+	server := newTestServer(t, opts...)
+	configFilePath := writeTestConfig(t, server, RestBizTestAccessID, RestBizTestKeyPath)
+	defer os.Remove(configFilePath)
+	restClient, err := NewRestClient(configFilePath)
+	require.Truef(t, err == nil, "failed to NewRestClient err:%+v", err)
+	return server, restClient
+}
+
+func deployEchoContract(t *testing.T, server *resttest.Server, restClient *RestClient, account, kmsId string) string {
+	u := uuid.New()
+	contractName := fmt.Sprintf("test_biz_deploy_contract_%v", u.String())
+	orderId := fmt.Sprintf("order_%v", u.String())
+	var gas int64 = 50000
+	server.HandleContract(contractName, func(param model.CallRestBizParam) ([]interface{}, []byte, error) {
+		outRes := make([]interface{}, 0)
+		err := json.Unmarshal([]byte(param.InputParamListStr), &outRes)
+		return outRes, nil, err
+	})
+	//deploy contract
+	baseResp, err := restClient.DeployContract(RestBizTestBizID, orderId, account, RestBizTestTenantID, kmsId, contractName, RestBizTestContractCode, gas)
+	require.Truef(t, err == nil && baseResp.Code == "200", "no succ resp baseResp:%+v err:%+v", baseResp, err)
+	return contractName
+}
+
+func TestNewRestClient(t *testing.T) {
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	require.NotEmpty(t, restClient.CurrentToken(), "rest token:%+v is empty", restClient.CurrentToken())
 }
 
 func TestNewRestClient_WrongConfigPath(t *testing.T) {
@@ -108,30 +224,33 @@ func TestNewRestClient_WrongConfigPath(t *testing.T) {
 }
 
 func TestNewRestClient_WrongConfigFile(t *testing.T) {
-	configFilePath := os.Getenv("GOPATH") + "/src/github.com/ctwel/antchain-client-go-sdk/test/rest-config-wrong.json"
+	configFilePath := "../test/rest-config-wrong.json"
 	_, err := NewRestClient(configFilePath)
 	require.Truef(t, err != nil, "cannot new restclient without right config file")
 }
 
 func TestNewRestClient_WrongAccessKey1(t *testing.T) {
-	configFilePath := os.Getenv("GOPATH") + "/src/github.com/ctwel/antchain-client-go-sdk/test/rest-config-wrong-access-key1.json"
+	server := newTestServer(t)
+	defer server.Close()
+	configFilePath := writeTestConfig(t, server, "wrong_access_id", RestBizTestKeyPath)
+	defer os.Remove(configFilePath)
 	_, err := NewRestClient(configFilePath)
 	require.Truef(t, err != nil, "cannot new restclient without right access key")
 }
 
 func TestNewRestClient_WrongAccessKey2(t *testing.T) {
-	configFilePath := os.Getenv("GOPATH") + "/src/github.com/ctwel/antchain-client-go-sdk/test/rest-config-wrong-access-key2.json"
+	server := newTestServer(t)
+	defer server.Close()
+	configFilePath := writeTestConfig(t, server, RestBizTestAccessID, RestBizTestOtherKeyPath)
+	defer os.Remove(configFilePath)
 	_, err := NewRestClient(configFilePath)
 	require.Truef(t, err != nil, "cannot new restclient without right access key")
 }
 
 func TestDeposit(t *testing.T) {
-	configFilePath := os.Getenv("GOPATH") + "/src/github.com/ctwel/antchain-client-go-sdk/test/rest-config.json"
-	restClient, err := NewRestClient(configFilePath)
-	if err != nil {
-		t.Errorf("failed to NewRestClient err:%+v", err)
-	}
-	require.NotEmpty(t, restClient.RestToken, "rest token:%+v is empty", restClient.RestToken)
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	require.NotEmpty(t, restClient.CurrentToken(), "rest token:%+v is empty", restClient.CurrentToken())
 
 	u := uuid.New()
 	orderId := fmt.Sprintf("order_%v", u.String())
@@ -141,22 +260,11 @@ func TestDeposit(t *testing.T) {
 	require.Truef(t, err == nil && baseResp.Code == "200", "no succ resp baseResp:%+v err:%+v", baseResp, err)
 
 	hash := baseResp.Data
-	tick := time.Tick(time.Duration(2000) * time.Millisecond) // wait 2s for tx finished...
-	select {
-	case <-tick:
-	}
-	baseResp, err = restClient.QueryReceipt(RestBizTestBizID, hash)
-	require.Truef(t, err == nil && baseResp.Code == "200", "no succ receipt baseResp:%+v err:%+v", baseResp, err)
-	baseResp, err = restClient.QueryTransaction(RestBizTestBizID, hash)
-	require.Truef(t, err == nil && baseResp.Code == "200", "no succ transaction baseResp:%+v err:%+v", baseResp, err)
-	jsonObject := make(map[string]interface{})
-	err = json.Unmarshal([]byte(baseResp.Data), &jsonObject)
-	if err != nil {
-		t.FailNow()
-	}
-	innerObject := jsonObject["transactionDO"].(map[string]interface{})
-	encodedData := innerObject["data"].(string)
-	bytes, err := base64.StdEncoding.DecodeString(encodedData)
+	_, err = restClient.QueryReceipt(RestBizTestBizID, hash)
+	require.Truef(t, err == nil, "no succ receipt err:%+v", err)
+	transaction, err := restClient.QueryTransaction(RestBizTestBizID, hash)
+	require.Truef(t, err == nil, "no succ transaction err:%+v", err)
+	bytes, err := base64.StdEncoding.DecodeString(transaction.Data)
 	if err != nil {
 		t.FailNow()
 	}
@@ -165,116 +273,146 @@ func TestDeposit(t *testing.T) {
 }
 
 func TestQueryTransaction(t *testing.T) {
-	configFilePath := os.Getenv("GOPATH") + "/src/github.com/ctwel/antchain-client-go-sdk/test/rest-config.json"
-	restClient, err := NewRestClient(configFilePath)
-	if err != nil {
-		t.Errorf("failed to NewRestClient err:%+v", err)
-	}
-	hash := "b457afacb11dff49020f70ea1a80059b2d98466a58399d36e5b71389827216b2"
-	baseResp, err := restClient.QueryTransaction(RestBizTestBizID, hash)
-	require.Truef(t, err == nil && baseResp.Code == "200", "no succ resp baseResp:%+v err:%+v", baseResp, err)
-	fmt.Printf("baseResp:%+v\n", baseResp)
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	baseResp, err := restClient.Deposit(RestBizTestBizID, "order_query_transaction", RestBizTestAccount, RestBizTestTenantID, "content", RestBizTestKmsID, 0)
+	require.Truef(t, err == nil && baseResp.Code == "200", "no succ deposit resp baseResp:%+v err:%+v", baseResp, err)
+	hash := baseResp.Data
+	transaction, err := restClient.QueryTransaction(RestBizTestBizID, hash)
+	require.Truef(t, err == nil && transaction.Hash == hash, "no succ transaction:%+v err:%+v", transaction, err)
+	_, err = restClient.QueryTransaction(RestBizTestBizID, "b457afacb11dff49020f70ea1a80059b2d98466a58399d36e5b71389827216b2")
+	require.Truef(t, errors.Is(err, response.ErrNotFound), "expect not found err:%+v", err)
 }
 
 func TestQueryReceipt(t *testing.T) {
-	configFilePath := os.Getenv("GOPATH") + "/src/github.com/ctwel/antchain-client-go-sdk/test/rest-config.json"
-	restClient, err := NewRestClient(configFilePath)
-	if err != nil {
-		t.Errorf("failed to NewRestClient err:%+v", err)
-	}
-	hash := "b457afacb11dff49020f70ea1a80059b2d98466a58399d36e5b71389827216b2"
-	baseResp, err := restClient.QueryReceipt(RestBizTestBizID, hash)
-	require.Truef(t, err == nil && baseResp.Code == "200", "no succ resp baseResp:%+v err:%+v", baseResp, err)
-	fmt.Printf("baseResp:%+v\n", baseResp)
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	baseResp, err := restClient.Deposit(RestBizTestBizID, "order_query_receipt", RestBizTestAccount, RestBizTestTenantID, "content", RestBizTestKmsID, 0)
+	require.Truef(t, err == nil && baseResp.Code == "200", "no succ deposit resp baseResp:%+v err:%+v", baseResp, err)
+	hash := baseResp.Data
+	receipt, err := restClient.QueryReceipt(RestBizTestBizID, hash)
+	require.Truef(t, err == nil && receipt.Hash == hash, "no succ receipt:%+v err:%+v", receipt, err)
 }
 
-/** 下面为测试合约的abi
+func TestQueryReceiptAndTransactionModels(t *testing.T) {
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	content := "content"
+	baseResp, err := restClient.Deposit(RestBizTestBizID, "order_query_models", RestBizTestAccount, RestBizTestTenantID, content, RestBizTestKmsID, 50000)
+	require.Truef(t, err == nil && baseResp.Code == "200", "no succ deposit resp baseResp:%+v err:%+v", baseResp, err)
+	hash := baseResp.Data
+	tx, ok := server.Transaction(hash)
+	require.True(t, ok)
+
+	receipt, err := restClient.QueryReceipt(RestBizTestBizID, hash)
+	require.NoError(t, err)
+	require.Equal(t, hash, receipt.Hash)
+	require.Equal(t, tx.BlockNumber, receipt.BlockNumber)
+	require.Equal(t, int64(0), receipt.Result)
+	require.Equal(t, int64(50000), receipt.GasUsed)
+
+	transaction, err := restClient.QueryTransaction(RestBizTestBizID, hash)
+	require.NoError(t, err)
+	require.Equal(t, hash, transaction.Hash)
+	require.Equal(t, tx.BlockNumber, transaction.BlockNumber)
+	require.Equal(t, mychain.NewIdentity(RestBizTestAccount).Hex(), transaction.From)
+	require.Equal(t, base64.StdEncoding.EncodeToString([]byte(content)), transaction.Data)
+	require.Len(t, transaction.Signatures, 1)
+
+	logs := []mychain.Log{{To: mychain.NewIdentity("contract").Hex(), Topics: []string{"topic"}, Data: "ZGF0YQ=="}}
+	hash = server.CommitWithLogs(model.CallRestBizParam{BaseParam: model.BaseParam{Method: model.CALLCONTRACTBIZASYNC}}, nil, logs)
+	receipt, err = restClient.QueryReceipt(RestBizTestBizID, hash)
+	require.NoError(t, err)
+	require.Equal(t, logs, receipt.Logs)
+
+	_, err = restClient.QueryReceipt(RestBizTestBizID, "b457afacb11dff49020f70ea1a80059b2d98466a58399d36e5b71389827216b2")
+	require.Truef(t, errors.Is(err, response.ErrNotFound), "expect not found err:%+v", err)
+	_, err = restClient.QueryTransaction(RestBizTestBizID, "b457afacb11dff49020f70ea1a80059b2d98466a58399d36e5b71389827216b2")
+	require.Truef(t, errors.Is(err, response.ErrNotFound), "expect not found err:%+v", err)
+}
+
+/*
+* 下面为测试合约的abi
 [
-  {
-    "constant": true,
-    "inputs": [
-      {
-        "name": "b",
-        "type": "bytes"
-      },
-      {
-        "name": "s",
-        "type": "string"
-      }
-    ],
-    "name": "SayHello",
-    "outputs": [
-      {
-        "name": "",
-        "type": "bytes"
-      },
-      {
-        "name": "",
-        "type": "string"
-      }
-    ],
-    "payable": false,
-    "stateMutability": "view",
-    "type": "function"
-  },
-  {
-    "constant": true,
-    "inputs": [],
-    "name": "beneficiary",
-    "outputs": [
-      {
-        "name": "",
-        "type": "identity"
-      }
-    ],
-    "payable": false,
-    "stateMutability": "view",
-    "type": "function"
-  },
-  {
-    "constant": true,
-    "inputs": [],
-    "name": "say",
-    "outputs": [
-      {
-        "name": "",
-        "type": "identity"
-      }
-    ],
-    "payable": false,
-    "stateMutability": "view",
-    "type": "function"
-  },
-  {
-    "inputs": [
-      {
-        "name": "_greeting",
-        "type": "uint256"
-      },
-      {
-        "name": "a",
-        "type": "string"
-      }
-    ],
-    "payable": false,
-    "stateMutability": "nonpayable",
-    "type": "constructor"
-  }
+
+	{
+	  "constant": true,
+	  "inputs": [
+	    {
+	      "name": "b",
+	      "type": "bytes"
+	    },
+	    {
+	      "name": "s",
+	      "type": "string"
+	    }
+	  ],
+	  "name": "SayHello",
+	  "outputs": [
+	    {
+	      "name": "",
+	      "type": "bytes"
+	    },
+	    {
+	      "name": "",
+	      "type": "string"
+	    }
+	  ],
+	  "payable": false,
+	  "stateMutability": "view",
+	  "type": "function"
+	},
+	{
+	  "constant": true,
+	  "inputs": [],
+	  "name": "beneficiary",
+	  "outputs": [
+	    {
+	      "name": "",
+	      "type": "identity"
+	    }
+	  ],
+	  "payable": false,
+	  "stateMutability": "view",
+	  "type": "function"
+	},
+	{
+	  "constant": true,
+	  "inputs": [],
+	  "name": "say",
+	  "outputs": [
+	    {
+	      "name": "",
+	      "type": "identity"
+	    }
+	  ],
+	  "payable": false,
+	  "stateMutability": "view",
+	  "type": "function"
+	},
+	{
+	  "inputs": [
+	    {
+	      "name": "_greeting",
+	      "type": "uint256"
+	    },
+	    {
+	      "name": "a",
+	      "type": "string"
+	    }
+	  ],
+	  "payable": false,
+	  "stateMutability": "nonpayable",
+	  "type": "constructor"
+	}
+
 ]
 */
 func TestDeployContractAndCallContract(t *testing.T) {
-	configFilePath := os.Getenv("GOPATH") + "/src/github.com/ctwel/antchain-client-go-sdk/test/rest-config.json"
-	restClient, err := NewRestClient(configFilePath)
-	if err != nil {
-		t.Errorf("failed to NewRestClient err:%+v", err)
-	}
-	u := uuid.New()
-	contractName := fmt.Sprintf("test_biz_deploy_contract_%v", u.String())
-	orderId := fmt.Sprintf("order_%v", u.String())
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	contractName := deployEchoContract(t, server, restClient, RestBizTestAccount, RestBizTestKmsID)
 	var gas int64 = 50000
-	//deploy contract
-	baseResp, err := restClient.DeployContract(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, contractName, "608060405234801561001057600080fd5b506040516102ef3803806102ef833981018060405281019080805190602001909291908051820192919050505081600081905550600060018190555050506102928061005d6000396000f300608060405260043610610057576000357c0100000000000000000000000000000000000000000000000000000000900463ffffffff1680631002aecd1461005c57806338af3eed146101f0578063954ab4b21461021b575b600080fd5b34801561006857600080fd5b50610109600480360381019080803590602001908201803590602001908080601f0160208091040260200160405190810160405280939291908181526020018383808284378201915050505050509192919290803590602001908201803590602001908080601f0160208091040260200160405190810160405280939291908181526020018383808284378201915050505050509192919290505050610246565b604051808060200180602001838103835285818151815260200191508051906020019080838360005b8381101561014d578082015181840152602081019050610132565b50505050905090810190601f16801561017a5780820380516001836020036101000a031916815260200191505b50838103825284818151815260200191508051906020019080838360005b838110156101b3578082015181840152602081019050610198565b50505050905090810190601f1680156101e05780820380516001836020036101000a031916815260200191505b5094505050505060405180910390f35b3480156101fc57600080fd5b50610205610256565b6040518082815260200191505060405180910390f35b34801561022757600080fd5b5061023061025c565b6040518082815260200191505060405180910390f35b6060808383915091509250929050565b60015481565b60006001549050905600a165627a7a72305820ac9ff0ce4f83f475e39f7a8ecdfeb0b16673a328ca1af858b2ce81ccbe75837c0029", gas)
-	require.Truef(t, err == nil && baseResp.Code == "200", "no succ resp baseResp:%+v err:%+v", baseResp, err)
 	//call contract
 	arg1 := make([]byte, 13)
 	for i := 0; i < 13; i++ {
@@ -288,9 +426,9 @@ func TestDeployContractAndCallContract(t *testing.T) {
 	if err != nil {
 		t.FailNow()
 	}
-	u = uuid.New()
-	orderId = fmt.Sprintf("order_%v", u.String())
-	baseResp, err = restClient.CallContract(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, contractName, "SayHello(bytes,string)", string(inputParamListBytes), `["bytes","string"]`, RestBizTestKmsID, false, gas)
+	u := uuid.New()
+	orderId := fmt.Sprintf("order_%v", u.String())
+	baseResp, err := restClient.CallContract(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, contractName, "SayHello(bytes,string)", string(inputParamListBytes), `["bytes","string"]`, RestBizTestKmsID, false, gas)
 	require.Truef(t, err == nil && baseResp.Success && baseResp.Code == "200", "no succ resp baseResp:%+v err:%+v", baseResp, err)
 	type Output struct {
 		OutRes []interface{} `json:"outRes"`
@@ -323,31 +461,220 @@ func TestDeployContractAndCallContract(t *testing.T) {
 	output2 = outputs.OutRes[1].(string)
 	require.Truef(t, isBytesSame(arg1, output1), "intput arg1:%+v is not same with output1:%+v", arg1, output1)
 	require.Truef(t, arg2 == output2, "input arg2:%s is not same with output2:%s", arg2, output2)
-	//async call and query receipts
-	//abi, err := abi.JSON(strings.NewReader(abiJsonStr))
-	//if err != nil {
-	//	t.FailNow()
-	//}
-	//sayHelloResp := &[]interface{}{&[]byte{}, new(string)}
-	//u = uuid.New()
-	//orderId = fmt.Sprintf("order_%v", u.String())
-	//u = uuid.New()
-	//orderId = fmt.Sprintf("order_%v", u.String())
-	//inputParamListStr := string(inputParamListBytes)
-	//resp, err := restClient.CallSolcContractSyncWithReceipt(abi, RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, contractName, "SayHello(bytes,string)", inputParamListStr, `["bytes","string"]`, gas, sayHelloResp)
-	//require.Truef(t, err == nil && resp.Success && resp.Code == "200", "callSolcContractAsyncWithReceipt failed resp:%+v err:%+v", resp, err)
-	//output1 = *(*sayHelloResp)[0].(*[]byte)
-	//output2 = *(*sayHelloResp)[1].(*string)
-	//require.Truef(t, isBytesSame(arg1, output1), "intput arg1:%+v is not same with output1:%+v", arg1, output1)
-	//require.Truef(t, arg2 == output2, "input arg2:%s is not same with output2:%s", arg2, output2)
+}
+
+func TestCallContractSyncWithReceipt(t *testing.T) {
+	server, restClient := newTestRestClient(t, resttest.WithPendingStates(1, 1))
+	defer server.Close()
+	contractAbi, err := abi.JSON(strings.NewReader(abiJsonStr))
+	require.NoError(t, err)
+	method, err := contractAbi.Method("SayHello")
+	require.NoError(t, err)
+	contractName := fmt.Sprintf("test_biz_sync_contract_%v", uuid.New().String())
+	server.HandleContract(contractName, func(param model.CallRestBizParam) ([]interface{}, []byte, error) {
+		inputs := make([]interface{}, 0)
+		if err := json.Unmarshal([]byte(param.InputParamListStr), &inputs); err != nil {
+			return nil, nil, err
+		}
+		arg1, err := base64.StdEncoding.DecodeString(inputs[0].(string))
+		if err != nil {
+			return nil, nil, err
+		}
+		output, err := method.Outputs.Pack(arg1, inputs[1])
+		if err != nil {
+			return nil, nil, err
+		}
+		if inputs[1] == "revert" {
+			return nil, output, &resttest.Revert{Result: 10201}
+		}
+		return nil, output, nil
+	})
+	var gas int64 = 50000
+	arg1 := []byte{0, 1, 2, 3}
+	arg2 := "hello"
+	inputParamListStr, err := contractAbi.EncodeInputParamList("SayHello", arg1, arg2)
+	require.NoError(t, err)
+
+	sayHelloResp := &[]interface{}{&[]byte{}, new(string)}
+	orderId := fmt.Sprintf("order_%v", uuid.New().String())
+	resp, err := restClient.CallContractSyncWithReceipt(contractAbi, RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, contractName, "SayHello(bytes,string)", inputParamListStr, `["bytes","string"]`, gas, sayHelloResp)
+	require.Truef(t, err == nil && resp.Success && resp.Code == "200", "callContractSyncWithReceipt failed resp:%+v err:%+v", resp, err)
+	require.Equal(t, arg1, *(*sayHelloResp)[0].(*[]byte))
+	require.Equal(t, arg2, *(*sayHelloResp)[1].(*string))
+
+	// outTypes are taken from the abi and the output is decoded positionally into a struct
+	type SayHelloOutput struct {
+		B []byte
+		S string
+	}
+	sayHelloOutput := &SayHelloOutput{}
+	orderId = fmt.Sprintf("order_%v", uuid.New().String())
+	resp, err = restClient.CallContractSyncWithReceipt(contractAbi, RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, contractName, "SayHello", inputParamListStr, "", gas, sayHelloOutput)
+	require.NoError(t, err)
+	require.Equal(t, SayHelloOutput{B: arg1, S: arg2}, *sayHelloOutput)
+	require.JSONEq(t, `{"B":"AAECAw==","S":"hello"}`, resp.Data)
+
+	inputParamListStr, err = contractAbi.EncodeInputParamList("SayHello", arg1, "revert")
+	require.NoError(t, err)
+	orderId = fmt.Sprintf("order_%v", uuid.New().String())
+	_, err = restClient.CallContractSyncWithReceipt(contractAbi, RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, contractName, "SayHello", inputParamListStr, "", gas, &SayHelloOutput{})
+	require.Truef(t, errors.Is(err, response.ErrTxFailed), "expect failed receipt err:%+v", err)
+
+	_, err = restClient.CallContractSyncWithReceipt(contractAbi, RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, contractName, "missing()", "[]", "", gas, &SayHelloOutput{})
+	require.Error(t, err)
+}
+
+func TestDeployWasmContractAndCallWasmContract(t *testing.T) {
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	contractName := fmt.Sprintf("test_biz_wasm_contract_%v", uuid.New().String())
+	server.HandleContract(contractName, func(param model.CallRestBizParam) ([]interface{}, []byte, error) {
+		inputs := make([]int32, 0)
+		if err := json.Unmarshal([]byte(param.InputParamListStr), &inputs); err != nil {
+			return nil, nil, err
+		}
+		return []interface{}{inputs[0] + inputs[1]}, nil, nil
+	})
+	var gas int64 = 50000
+	orderId := fmt.Sprintf("order_%v", uuid.New().String())
+	_, err := restClient.DeployWasmContract(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, contractName, "0061736d", gas)
+	require.Truef(t, errors.Is(err, response.ErrValidation), "expect malformed module err:%+v", err)
+	_, err = restClient.DeployWasmContract(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, contractName, RestBizTestWasmContractCode, gas, "add(int32,int64)")
+	require.Truef(t, errors.Is(err, response.ErrValidation), "expect incompatible signature err:%+v", err)
+	require.Zero(t, server.Calls(model.DEPLOYWASMCONTRACT))
+	baseResp, err := restClient.DeployWasmContract(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, contractName, RestBizTestWasmContractCode, gas, "add(int32,int32)")
+	require.Truef(t, err == nil && baseResp.Success && baseResp.Code == "200", "no succ resp baseResp:%+v err:%+v", baseResp, err)
+	tx, ok := server.Transaction(baseResp.Data)
+	require.True(t, ok)
+	require.Equal(t, model.VMTypeEnum(model.WASM), tx.Param.VmTypeEnum)
+
+	orderId = fmt.Sprintf("order_%v", uuid.New().String())
+	baseResp, err = restClient.CallWasmContract(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, contractName, "add(int32,int32)", "[1,2]", `["int32"]`, RestBizTestKmsID, false, gas)
+	require.Truef(t, err == nil && baseResp.Success && baseResp.Code == "200", "no succ resp baseResp:%+v err:%+v", baseResp, err)
+	require.JSONEq(t, `{"outRes":[3]}`, baseResp.Data)
+
+	orderId = fmt.Sprintf("order_%v", uuid.New().String())
+	baseResp, err = restClient.CallWasmContractAsync(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, contractName, "add(int32,int32)", "[1,2]", `["int32"]`, RestBizTestKmsID, gas)
+	require.Truef(t, err == nil && baseResp.Success && baseResp.Code == "200", "no succ resp baseResp:%+v err:%+v", baseResp, err)
+	result, err := restClient.WaitForReceipt(RestBizTestBizID, baseResp.Data, WaitOptions{})
+	require.NoError(t, err)
+	require.Equal(t, WaitStateDone, result.State)
+	tx, ok = server.Transaction(baseResp.Data)
+	require.True(t, ok)
+	require.Equal(t, model.Method(model.CALLWASMCONTRACTASYNC), tx.Param.Method)
+	require.Equal(t, model.VMTypeEnum(model.WASM), tx.Param.VmTypeEnum)
+
+	_, err = restClient.CallWasmContract(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, contractName, "add(int32,int32)", "[1,2]", "", RestBizTestKmsID, false, gas)
+	require.Truef(t, errors.Is(err, response.ErrValidation), "expect validation err:%+v", err)
+}
+
+func TestDeployNativeContractAndCallNativeContract(t *testing.T) {
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	contractName := fmt.Sprintf("test_biz_native_contract_%v", uuid.New().String())
+	server.HandleContract(contractName, func(param model.CallRestBizParam) ([]interface{}, []byte, error) {
+		return []interface{}{param.NativeContractData}, nil, nil
+	})
+	var gas int64 = 50000
+	orderId := fmt.Sprintf("order_%v", uuid.New().String())
+	_, err := restClient.DeployNativeContract(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, "", contractName, "native", gas)
+	require.Truef(t, errors.Is(err, response.ErrValidation), "expect missing kms id err:%+v", err)
+	require.Zero(t, server.Calls(model.DEPLOYNATIVECONTRACT))
+	baseResp, err := restClient.DeployNativeContract(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, contractName, "native", gas)
+	require.Truef(t, err == nil && baseResp.Success && baseResp.Code == "200", "no succ resp baseResp:%+v err:%+v", baseResp, err)
+	tx, ok := server.Transaction(baseResp.Data)
+	require.True(t, ok)
+	require.Equal(t, model.VMTypeEnum(model.NATIVE), tx.Param.VmTypeEnum)
+
+	orderId = fmt.Sprintf("order_%v", uuid.New().String())
+	baseResp, err = restClient.CallNativeContract(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, contractName, "echo", "0a04746573", RestBizTestKmsID, gas)
+	require.Truef(t, err == nil && baseResp.Success && baseResp.Code == "200", "no succ resp baseResp:%+v err:%+v", baseResp, err)
+	require.JSONEq(t, `{"outRes":["0a04746573"]}`, baseResp.Data)
+
+	orderId = fmt.Sprintf("order_%v", uuid.New().String())
+	baseResp, err = restClient.CallNativeContractAsync(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, contractName, "echo", "0a04746573", RestBizTestKmsID, gas)
+	require.Truef(t, err == nil && baseResp.Success && baseResp.Code == "200", "no succ resp baseResp:%+v err:%+v", baseResp, err)
+	result, err := restClient.WaitForReceipt(RestBizTestBizID, baseResp.Data, WaitOptions{})
+	require.NoError(t, err)
+	require.Equal(t, WaitStateDone, result.State)
+	tx, ok = server.Transaction(baseResp.Data)
+	require.True(t, ok)
+	require.Equal(t, model.Method(model.CALLNATIVECONTRACTFORBIZASYNC), tx.Param.Method)
+	require.Equal(t, model.VMTypeEnum(model.NATIVE), tx.Param.VmTypeEnum)
+
+	_, err = restClient.CallNativeContract(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, contractName, "echo", "", RestBizTestKmsID, gas)
+	require.Truef(t, errors.Is(err, response.ErrValidation), "expect validation err:%+v", err)
+
+	orderId = fmt.Sprintf("order_%v", uuid.New().String())
+	baseResp, err = restClient.CallNativeContractNonBiz(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, contractName, "echo", "0a04746573", RestBizTestKmsID, gas)
+	require.Truef(t, err == nil && baseResp.Success && baseResp.Code == "200", "no succ resp baseResp:%+v err:%+v", baseResp, err)
+	require.JSONEq(t, `{"outRes":["0a04746573"]}`, baseResp.Data)
+	require.Equal(t, 1, server.Calls(model.CALLNATIVECONTRACT))
+
+	orderId = fmt.Sprintf("order_%v", uuid.New().String())
+	baseResp, err = restClient.CallNativeContractNonBizAsync(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, contractName, "echo", "0a04746573", RestBizTestKmsID, gas)
+	require.Truef(t, err == nil && baseResp.Success && baseResp.Code == "200", "no succ resp baseResp:%+v err:%+v", baseResp, err)
+	tx, ok = server.Transaction(baseResp.Data)
+	require.True(t, ok)
+	require.Equal(t, model.Method(model.CALLNATIVECONTRACTASYNC), tx.Param.Method)
+
+	_, err = restClient.CallNativeContractNonBizAsync(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, contractName, "", "0a04746573", RestBizTestKmsID, gas)
+	require.Truef(t, errors.Is(err, response.ErrValidation), "expect validation err:%+v", err)
+}
+
+func TestUpdateContract(t *testing.T) {
+	server, restClient := newTestRestClient(t, resttest.WithPendingStates(1, 1))
+	defer server.Close()
+	registeredAbi, err := abi.JSON(strings.NewReader(abiJsonStr))
+	require.NoError(t, err)
+	upgradedAbi, err := abi.JSON(strings.NewReader(strings.Replace(abiJsonStr, `"name": "say"`, `"name": "talk"`, 1)))
+	require.NoError(t, err)
+	contractName := fmt.Sprintf("test_biz_update_contract_%v", uuid.New().String())
+	var gas int64 = 50000
+
+	orderId := fmt.Sprintf("order_%v", uuid.New().String())
+	opts := UpdateContractOptions{RegisteredAbi: &registeredAbi, Abi: &upgradedAbi}
+	_, err = restClient.UpdateContract(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, contractName, "6080", gas, opts)
+	require.Truef(t, errors.Is(err, response.ErrValidation), "expect breaking change err:%+v", err)
+	opts = UpdateContractOptions{VmType: model.WASM, RegisteredCode: RestBizTestWasmContractCode}
+	// exports sub instead of add
+	wasmCode := strings.Replace(strings.Replace(RestBizTestWasmContractCode, "616464", "737562", 1), "6a0b", "6b0b", 1)
+	_, err = restClient.UpdateContract(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, contractName, wasmCode, gas, opts)
+	require.Truef(t, errors.Is(err, response.ErrValidation), "expect removed export err:%+v", err)
+	_, err = restClient.UpdateContract(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, contractName, "", gas, UpdateContractOptions{})
+	require.Truef(t, errors.Is(err, response.ErrValidation), "expect missing code err:%+v", err)
+	require.Zero(t, server.Calls(model.UPDATECONTRACTFORBIZ))
+
+	baseResp, err := restClient.UpdateContract(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, contractName, "6080", gas, UpdateContractOptions{RegisteredAbi: &registeredAbi, Abi: &registeredAbi})
+	require.Truef(t, err == nil && baseResp.Success && baseResp.Code == "200", "no succ resp baseResp:%+v err:%+v", baseResp, err)
+	tx, ok := server.Transaction(baseResp.Data)
+	require.True(t, ok)
+	require.Equal(t, model.Method(model.UPDATECONTRACTFORBIZ), tx.Param.Method)
+	require.Equal(t, model.VMTypeEnum(model.EVM), tx.Param.VmTypeEnum)
+
+	orderId = fmt.Sprintf("order_%v", uuid.New().String())
+	opts = UpdateContractOptions{VmType: model.WASM, RegisteredCode: RestBizTestWasmContractCode, Wait: true}
+	baseResp, err = restClient.UpdateContract(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, contractName, RestBizTestWasmContractCode, gas, opts)
+	require.Truef(t, err == nil && baseResp.Success && baseResp.Code == "200", "no succ resp baseResp:%+v err:%+v", baseResp, err)
+	receipt := mychain.TransactionReceipt{}
+	require.NoError(t, json.Unmarshal([]byte(baseResp.Data), &receipt))
+	require.Zero(t, receipt.Result)
+	require.Equal(t, 2, server.Calls(model.UPDATECONTRACTFORBIZ))
+
+	orderId = fmt.Sprintf("order_%v", uuid.New().String())
+	_, err = restClient.UpdateContract(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, "", "6080", gas, UpdateContractOptions{NonBiz: true})
+	require.Truef(t, errors.Is(err, response.ErrValidation), "expect missing contract name err:%+v", err)
+	baseResp, err = restClient.UpdateContract(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, contractName, "6080", gas, UpdateContractOptions{NonBiz: true})
+	require.Truef(t, err == nil && baseResp.Success && baseResp.Code == "200", "no succ resp baseResp:%+v err:%+v", baseResp, err)
+	tx, ok = server.Transaction(baseResp.Data)
+	require.True(t, ok)
+	require.Equal(t, model.Method(model.UPDATECONTRACT), tx.Param.Method)
+	require.Equal(t, 2, server.Calls(model.UPDATECONTRACTFORBIZ))
 }
 
 func TestDepositSyncWithTransaction(t *testing.T) {
-	configFilePath := os.Getenv("GOPATH") + "/src/github.com/ctwel/antchain-client-go-sdk/test/rest-config.json"
-	restClient, err := NewRestClient(configFilePath)
-	if err != nil {
-		t.Errorf("failed to NewRestClient err:%+v", err)
-	}
+	server, restClient := newTestRestClient(t, resttest.WithPendingStates(1, 1))
+	defer server.Close()
 	u := uuid.New()
 	orderId := fmt.Sprintf("order_%v", u.String())
 	content := "我是中国人"
@@ -370,11 +697,8 @@ func TestDepositSyncWithTransaction(t *testing.T) {
 }
 
 func TestCreateAndQueryAccountWithKmsIdAndDeposit(t *testing.T) {
-	configFilePath := os.Getenv("GOPATH") + "/src/github.com/ctwel/antchain-client-go-sdk/test/rest-config.json"
-	restClient, err := NewRestClient(configFilePath)
-	if err != nil {
-		t.Errorf("failed to NewRestClient err:%+v", err)
-	}
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
 	u := uuid.New()
 	orderId := fmt.Sprintf("order_%v", u.String())
 	kmsId := fmt.Sprintf("%s_%s", RestBizTestTenantID, u.String())
@@ -392,7 +716,6 @@ func TestCreateAndQueryAccountWithKmsIdAndDeposit(t *testing.T) {
 	}
 	status := jsonObject["status"].(float64)
 	require.Truef(t, status == 0, "account status is wrong,status:%v", status)
-	fmt.Printf("%+v\n", jsonObject)
 
 	u = uuid.New()
 	orderId = fmt.Sprintf("order_%v", u.String())
@@ -401,17 +724,9 @@ func TestCreateAndQueryAccountWithKmsIdAndDeposit(t *testing.T) {
 	baseResp, err = restClient.Deposit(RestBizTestBizID, orderId, account, RestBizTestTenantID, content, kmsId, gas)
 	require.Truef(t, err == nil && baseResp.Code == "200", "no succ deposit resp,resp:%+v err:%+v", baseResp, err)
 	hash := baseResp.Data
-	time.Sleep(2 * time.Second) // wait for some time
-	baseResp, err = restClient.QueryTransaction(RestBizTestBizID, hash)
-	require.Truef(t, err == nil && baseResp.Code == "200", "no succ transaction baseResp:%+v err:%+v", baseResp, err)
-	jsonObject = make(map[string]interface{})
-	err = json.Unmarshal([]byte(baseResp.Data), &jsonObject)
-	if err != nil {
-		t.FailNow()
-	}
-	innerObject := jsonObject["transactionDO"].(map[string]interface{})
-	encodedData := innerObject["data"].(string)
-	bytes, err := base64.StdEncoding.DecodeString(encodedData)
+	transaction, err := restClient.QueryTransaction(RestBizTestBizID, hash)
+	require.Truef(t, err == nil, "no succ transaction err:%+v", err)
+	bytes, err := base64.StdEncoding.DecodeString(transaction.Data)
 	if err != nil {
 		t.FailNow()
 	}
@@ -420,11 +735,8 @@ func TestCreateAndQueryAccountWithKmsIdAndDeposit(t *testing.T) {
 }
 
 func TestCreateAndQueryAccountWithKmsIdAndDeployCallContract(t *testing.T) {
-	configFilePath := os.Getenv("GOPATH") + "/src/github.com/ctwel/antchain-client-go-sdk/test/rest-config.json"
-	restClient, err := NewRestClient(configFilePath)
-	if err != nil {
-		t.Errorf("failed to NewRestClient err:%+v", err)
-	}
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
 	u := uuid.New()
 	orderId := fmt.Sprintf("order_%v", u.String())
 	kmsId := fmt.Sprintf("%s_%s", RestBizTestTenantID, u.String())
@@ -442,17 +754,9 @@ func TestCreateAndQueryAccountWithKmsIdAndDeployCallContract(t *testing.T) {
 	}
 	status := jsonObject["status"].(float64)
 	require.Truef(t, status == 0, "account status is wrong,status:%v", status)
-	fmt.Printf("%+v\n", jsonObject)
 
-	u = uuid.New()
-	contractName := fmt.Sprintf("test_biz_deploy_contract_%v", u.String())
-
-	u = uuid.New()
-	orderId = fmt.Sprintf("order_%v", u.String())
+	contractName := deployEchoContract(t, server, restClient, account, kmsId)
 	var gas int64 = 50000
-	//deploy contract
-	baseResp, err = restClient.DeployContract(RestBizTestBizID, orderId, account, RestBizTestTenantID, kmsId, contractName, "608060405234801561001057600080fd5b506040516102ef3803806102ef833981018060405281019080805190602001909291908051820192919050505081600081905550600060018190555050506102928061005d6000396000f300608060405260043610610057576000357c0100000000000000000000000000000000000000000000000000000000900463ffffffff1680631002aecd1461005c57806338af3eed146101f0578063954ab4b21461021b575b600080fd5b34801561006857600080fd5b50610109600480360381019080803590602001908201803590602001908080601f0160208091040260200160405190810160405280939291908181526020018383808284378201915050505050509192919290803590602001908201803590602001908080601f0160208091040260200160405190810160405280939291908181526020018383808284378201915050505050509192919290505050610246565b604051808060200180602001838103835285818151815260200191508051906020019080838360005b8381101561014d578082015181840152602081019050610132565b50505050905090810190601f16801561017a5780820380516001836020036101000a031916815260200191505b50838103825284818151815260200191508051906020019080838360005b838110156101b3578082015181840152602081019050610198565b50505050905090810190601f1680156101e05780820380516001836020036101000a031916815260200191505b5094505050505060405180910390f35b3480156101fc57600080fd5b50610205610256565b6040518082815260200191505060405180910390f35b34801561022757600080fd5b5061023061025c565b6040518082815260200191505060405180910390f35b6060808383915091509250929050565b60015481565b60006001549050905600a165627a7a72305820ac9ff0ce4f83f475e39f7a8ecdfeb0b16673a328ca1af858b2ce81ccbe75837c0029", gas)
-	require.Truef(t, err == nil && baseResp.Code == "200", "no succ resp baseResp:%+v err:%+v", baseResp, err)
 	//call contract
 	arg1 := make([]byte, 13)
 	for i := 0; i < 13; i++ {
@@ -488,15 +792,60 @@ func TestCreateAndQueryAccountWithKmsIdAndDeployCallContract(t *testing.T) {
 }
 
 func TestRestClient_MultipleQueryReceipt(t *testing.T) {
-	configFilePath := os.Getenv("GOPATH") + "/src/github.com/ctwel/antchain-client-go-sdk/test/rest-config.json"
-	restClient, err := NewRestClient(configFilePath)
-	if err != nil {
-		t.Errorf("failed to NewRestClient err:%+v", err)
-	}
-	hash := "b457afacb11dff49020f70ea1a80059b2d98466a58399d36e5b71389827216b2"
-	baseResp, err := restClient.MultipleQueryReceipt(RestBizTestBizID, hash)
+	server, restClient := newTestRestClient(t, resttest.WithPendingStates(2, 1))
+	defer server.Close()
+	baseResp, err := restClient.Deposit(RestBizTestBizID, "order_multiple_query_receipt", RestBizTestAccount, RestBizTestTenantID, "content", RestBizTestKmsID, 0)
+	require.Truef(t, err == nil && baseResp.Code == "200", "no succ deposit resp baseResp:%+v err:%+v", baseResp, err)
+	hash := baseResp.Data
+	baseResp, err = restClient.MultipleQueryReceipt(RestBizTestBizID, hash)
 	require.Truef(t, err == nil && baseResp.Code == "200", "no succ resp baseResp:%+v err:%+v", baseResp, err)
-	fmt.Printf("baseResp:%+v\n", baseResp)
+}
+
+func TestRestClient_TokenExpired(t *testing.T) {
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	server.ExpireTokens()
+	baseResp, err := restClient.QueryAccount(RestBizTestBizID, RestBizTestAccount)
+	require.Truef(t, err == nil && baseResp.Code == "200", "no succ resp after token expired baseResp:%+v err:%+v", baseResp, err)
+	require.Equal(t, 2, server.ShakeHands())
+}
+
+func TestRestClient_TokenExpiredConcurrently(t *testing.T) {
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	server.ExpireTokens()
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			baseResp, err := restClient.QueryAccount(RestBizTestBizID, RestBizTestAccount)
+			if err == nil && !baseResp.Success {
+				err = baseResp.Err()
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.Truef(t, err == nil, "no succ resp after token expired err:%+v", err)
+	}
+	require.Equal(t, 2, server.ShakeHands())
+}
+
+func TestRestClient_ServerFault(t *testing.T) {
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	server.InjectFault(resttest.Fault{Method: model.QUERYACCOUNT, Code: "500", Data: "busy", Times: 2})
+	baseResp, err := restClient.QueryAccount(RestBizTestBizID, RestBizTestAccount)
+	require.Truef(t, err == nil && baseResp.Code == "200", "no succ resp after server fault baseResp:%+v err:%+v", baseResp, err)
+	require.Equal(t, 3, server.Calls(model.QUERYACCOUNT))
+
+	server.InjectFault(resttest.Fault{Method: model.QUERYACCOUNT, StatusCode: http.StatusForbidden})
+	_, err = restClient.QueryAccount(RestBizTestBizID, RestBizTestAccount)
+	require.Truef(t, errors.Is(err, response.ErrNon2xxStatus), "expect non 2xx status err:%+v", err)
 }
 
 func isBytesSame(a, b []byte) bool {
@@ -510,3 +859,107 @@ func isBytesSame(a, b []byte) bool {
 	}
 	return true
 }
+
+type staticSigner struct{}
+
+func (staticSigner) Sign(plain string) (string, error) {
+	return "signature", nil
+}
+
+func newShakeHandServer(chainCall http.HandlerFunc) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == ShakeHandPath {
+			fmt.Fprint(w, `{"success":true,"code":"200","data":"token"}`)
+			return
+		}
+		chainCall(w, r)
+	}))
+}
+
+func TestNewRestClientFromReader(t *testing.T) {
+	server := newShakeHandServer(nil)
+	defer server.Close()
+	restClient, err := NewRestClientFromReader(strings.NewReader(fmt.Sprintf(`{"RestUrl":"%v","AccessId":"accessId","AccessSecret":"unused"}`, server.URL)),
+		WithSigner(staticSigner{}), WithHTTPClient(server.Client()))
+	require.Truef(t, err == nil, "fail to new restclient from reader,err:%+v", err)
+	require.Equal(t, "token", restClient.CurrentToken())
+	require.Equal(t, "token", restClient.RestToken)
+	require.Equal(t, "accessId", restClient.RestClientProperties.AccessId)
+}
+
+func TestNewRestClientWithNilLogger(t *testing.T) {
+	server := newShakeHandServer(nil)
+	defer server.Close()
+	restClient, err := NewRestClientFromProperties(config.RestClientProperties{RestUrl: server.URL, AccessId: "accessId"},
+		WithSigner(staticSigner{}), WithHTTPClient(server.Client()), WithLogger(nil))
+	require.Truef(t, err == nil, "fail to new restclient with nil logger,err:%+v", err)
+	require.NotNil(t, restClient.logger)
+}
+
+func TestNewRestClientFromEnv(t *testing.T) {
+	server := newShakeHandServer(nil)
+	defer server.Close()
+	os.Setenv(config.DefaultEnvPrefix+"URL", server.URL)
+	os.Setenv(config.DefaultEnvPrefix+"ACCESS_ID", "accessId")
+	os.Setenv(config.DefaultEnvPrefix+"ACCESS_SECRET", "unused")
+	os.Setenv(config.DefaultEnvPrefix+"RETRY_MAX_ATTEMPTS", "7")
+	defer func() {
+		for _, name := range []string{"URL", "ACCESS_ID", "ACCESS_SECRET", "RETRY_MAX_ATTEMPTS"} {
+			os.Unsetenv(config.DefaultEnvPrefix + name)
+		}
+	}()
+	restClient, err := NewRestClientFromEnv(WithSigner(staticSigner{}), WithHTTPClient(server.Client()))
+	require.Truef(t, err == nil, "fail to new restclient from env,err:%+v", err)
+	require.Equal(t, 7, restClient.RestClientProperties.RetryMaxAttempts)
+}
+
+func TestRestClient_ChainCallWithContext_Canceled(t *testing.T) {
+	release := make(chan struct{})
+	server := newShakeHandServer(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	})
+	defer server.Close()
+	defer close(release)
+	restClient, err := NewRestClientFromProperties(config.RestClientProperties{RestUrl: server.URL, AccessId: "accessId", RetryMaxAttempts: 3},
+		WithSigner(staticSigner{}), WithHTTPClient(server.Client()))
+	require.Truef(t, err == nil, "fail to new restclient,err:%+v", err)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = restClient.ChainCallWithContext(ctx, "hash", RestBizTestBizID, "", model.QUERYRECEIPT)
+	require.Truef(t, err == context.DeadlineExceeded, "expect deadline exceeded,err:%+v", err)
+	require.Truef(t, time.Since(start) < 2*time.Second, "chain call did not honor context deadline")
+}
+
+func TestRestClient_RetryNonIdempotent(t *testing.T) {
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	server.InjectFault(resttest.Fault{Method: model.DEPOSIT, Code: "500", Data: "busy"})
+	_, err := restClient.Deposit(RestBizTestBizID, "order_retry_non_idempotent", RestBizTestAccount, RestBizTestTenantID, "content", RestBizTestKmsID, 0)
+	require.Truef(t, errors.Is(err, response.ErrServer), "expect server err:%+v", err)
+	require.Equal(t, 1, server.Calls(model.DEPOSIT))
+
+	server.InjectFault(resttest.Fault{Method: model.DEPOSIT, StatusCode: http.StatusServiceUnavailable, Times: 2})
+	baseResp, err := restClient.Deposit(RestBizTestBizID, "order_retry_non_idempotent", RestBizTestAccount, RestBizTestTenantID, "content", RestBizTestKmsID, 0)
+	require.Truef(t, err == nil && baseResp.Code == "200", "no succ resp after 503 baseResp:%+v err:%+v", baseResp, err)
+	require.Equal(t, 4, server.Calls(model.DEPOSIT))
+}
+
+func TestRestClient_RetryConnectionClosed(t *testing.T) {
+	var calls int32
+	server := newShakeHandServer(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		fmt.Fprint(w, `{"success":true,"code":"200","data":"{\"gasUsed\":7}"}`)
+	})
+	defer server.Close()
+	restClient, err := NewRestClientFromProperties(config.RestClientProperties{RestUrl: server.URL, AccessId: "accessId"},
+		WithSigner(staticSigner{}), WithHTTPClient(server.Client()), WithRetryPolicy(NewFixedRetryPolicy(3, time.Millisecond)))
+	require.Truef(t, err == nil, "fail to new restclient,err:%+v", err)
+	receipt, err := restClient.QueryReceipt(RestBizTestBizID, "hash")
+	require.Truef(t, err == nil && receipt.GasUsed == 7, "no succ receipt after connection closed receipt:%+v err:%+v", receipt, err)
+	require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RetryOutcome describes a failed attempt of a chain call.
+type RetryOutcome struct {
+	Method     model.Method
+	Idempotent bool          // whether resending the request cannot apply it twice
+	Err        error         // transport error, nil when a response was received
+	StatusCode int           // non 2xx http status
+	Code       string        // code of an unsuccessful BaaS response
+	RetryAfter time.Duration // parsed Retry-After header, 0 when absent
+}
+
+// RetryPolicy decides whether and when a failed chain call is sent again.
+type RetryPolicy interface {
+	// Backoff is called after the attempt-th attempt failed with outcome, it returns how long to wait
+	// before the next attempt and false to give up.
+	Backoff(attempt int, outcome RetryOutcome) (time.Duration, bool)
+}
+
+// IsRetryable classifies an outcome. Expired tokens, 429 and 503 mean the request was not executed
+// and are always retryable; transport errors, 502, 504 and BaaS 5xx codes are only retryable for
+// idempotent requests since the request may have been applied.
+func IsRetryable(outcome RetryOutcome) bool {
+	switch {
+	case outcome.Code == model.ServiceTokenExpired:
+		return true
+	case outcome.StatusCode == http.StatusTooManyRequests || outcome.StatusCode == http.StatusServiceUnavailable:
+		return true
+	case outcome.StatusCode == http.StatusBadGateway || outcome.StatusCode == http.StatusGatewayTimeout:
+		return outcome.Idempotent
+	case outcome.StatusCode != 0:
+		return false
+	case outcome.Err != nil:
+		return outcome.Idempotent
+	case strings.HasPrefix(outcome.Code, "5"):
+		return outcome.Idempotent
+	}
+	return false
+}
+
+var idempotentMethods = map[model.Method]bool{
+	model.QUERYRECEIPT:                   true,
+	model.QUERYTRANSACTION:               true,
+	model.QUERYTRANSACTIONFROMBLOCKCHAIN: true,
+	model.QUERYRECEIPTBIZ:                true,
+	model.QUERYTRANSACTIONBIZ:            true,
+	model.QUERYBLOCK:                     true,
+	model.QUERYBLOCKBODY:                 true,
+	model.QUERYLASTBLOCK:                 true,
+	model.QUERYBLOCKHEADERINFOSRAW:       true,
+	model.QUERYACCOUNT:                   true,
+	model.QUERYACCESSLIST:                true,
+	model.QUERYTENANTKMSLIST:             true,
+	model.GETMYTFINFO:                    true,
+	model.GETTAPPINFO:                    true,
+	model.GETRESOURCEMAP:                 true,
+	model.GETEVENTTOPICBLOCKNUM:          true,
+	model.PARSEOUTPUT:                    true,
+}
+
+// IsIdempotent reports whether method only reads chain state.
+func IsIdempotent(method model.Method) bool {
+	return idempotentMethods[method]
+}
+
+type fixedRetryPolicy struct {
+	maxAttempts int
+	period      time.Duration
+}
+
+// NewFixedRetryPolicy retries retryable outcomes every period, up to maxAttempts attempts in total.
+func NewFixedRetryPolicy(maxAttempts int, period time.Duration) RetryPolicy {
+	return &fixedRetryPolicy{maxAttempts: maxAttempts, period: period}
+}
+
+func (policy *fixedRetryPolicy) Backoff(attempt int, outcome RetryOutcome) (time.Duration, bool) {
+	if attempt >= policy.maxAttempts || !IsRetryable(outcome) {
+		return 0, false
+	}
+	return maxDuration(policy.period, outcome.RetryAfter), true
+}
+
+type exponentialRetryPolicy struct {
+	maxAttempts int
+	base        time.Duration
+	max         time.Duration
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// NewExponentialRetryPolicy retries retryable outcomes up to maxAttempts attempts in total, waiting
+// base doubled on every attempt and capped at max, with the upper half of the wait jittered.
+func NewExponentialRetryPolicy(maxAttempts int, base, max time.Duration) RetryPolicy {
+	return &exponentialRetryPolicy{
+		maxAttempts: maxAttempts,
+		base:        base,
+		max:         max,
+		rand:        rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (policy *exponentialRetryPolicy) Backoff(attempt int, outcome RetryOutcome) (time.Duration, bool) {
+	if attempt >= policy.maxAttempts || !IsRetryable(outcome) {
+		return 0, false
+	}
+	backoff := policy.max
+	if shift := uint(attempt - 1); shift < 32 && policy.base<<shift < policy.max {
+		backoff = policy.base << shift
+	}
+	if half := backoff / 2; half > 0 {
+		policy.mu.Lock()
+		backoff = half + time.Duration(policy.rand.Int63n(int64(half)+1))
+		policy.mu.Unlock()
+	}
+	return maxDuration(backoff, outcome.RetryAfter), true
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an http date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"errors"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestIsRetryable(t *testing.T) {
+	networkErr := errors.New("connection reset")
+	cases := []struct {
+		outcome   RetryOutcome
+		retryable bool
+	}{
+		{RetryOutcome{Method: model.DEPOSIT, Code: "202"}, true},
+		{RetryOutcome{Method: model.DEPOSIT, StatusCode: http.StatusTooManyRequests}, true},
+		{RetryOutcome{Method: model.DEPOSIT, StatusCode: http.StatusServiceUnavailable}, true},
+		{RetryOutcome{Method: model.DEPOSIT, StatusCode: http.StatusBadGateway}, false},
+		{RetryOutcome{Method: model.QUERYRECEIPT, Idempotent: true, StatusCode: http.StatusBadGateway}, true},
+		{RetryOutcome{Method: model.QUERYRECEIPT, Idempotent: true, StatusCode: http.StatusGatewayTimeout}, true},
+		{RetryOutcome{Method: model.QUERYRECEIPT, Idempotent: true, StatusCode: http.StatusForbidden}, false},
+		{RetryOutcome{Method: model.DEPOSIT, Err: networkErr}, false},
+		{RetryOutcome{Method: model.QUERYRECEIPT, Idempotent: true, Err: networkErr}, true},
+		{RetryOutcome{Method: model.DEPOSIT, Code: "500"}, false},
+		{RetryOutcome{Method: model.QUERYRECEIPT, Idempotent: true, Code: "500"}, true},
+		{RetryOutcome{Method: model.QUERYRECEIPT, Idempotent: true, Code: "400"}, false},
+	}
+	for _, c := range cases {
+		require.Equalf(t, c.retryable, IsRetryable(c.outcome), "outcome:%+v", c.outcome)
+	}
+	require.True(t, IsIdempotent(model.QUERYRECEIPT))
+	require.False(t, IsIdempotent(model.DEPOSIT))
+}
+
+func TestFixedRetryPolicy(t *testing.T) {
+	policy := NewFixedRetryPolicy(3, 100*time.Millisecond)
+	outcome := RetryOutcome{Code: "202"}
+	backoff, retry := policy.Backoff(1, outcome)
+	require.True(t, retry)
+	require.Equal(t, 100*time.Millisecond, backoff)
+	_, retry = policy.Backoff(3, outcome)
+	require.False(t, retry)
+	outcome.RetryAfter = time.Second
+	backoff, _ = policy.Backoff(1, outcome)
+	require.Equal(t, time.Second, backoff)
+}
+
+func TestExponentialRetryPolicy(t *testing.T) {
+	policy := NewExponentialRetryPolicy(10, 100*time.Millisecond, time.Second)
+	outcome := RetryOutcome{StatusCode: http.StatusServiceUnavailable}
+	for attempt := 1; attempt < 10; attempt++ {
+		backoff, retry := policy.Backoff(attempt, outcome)
+		require.True(t, retry)
+		ceiling := 100 * time.Millisecond << uint(attempt-1)
+		if ceiling > time.Second {
+			ceiling = time.Second
+		}
+		require.Truef(t, backoff >= ceiling/2 && backoff <= ceiling, "attempt:%v backoff:%v ceiling:%v", attempt, backoff, ceiling)
+	}
+	_, retry := policy.Backoff(10, outcome)
+	require.False(t, retry)
+	_, retry = policy.Backoff(1, RetryOutcome{Method: model.DEPOSIT, Code: "500"})
+	require.False(t, retry)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, 3*time.Second, parseRetryAfter("3", now))
+	require.Equal(t, time.Duration(0), parseRetryAfter("", now))
+	require.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+	require.Equal(t, 10*time.Second, parseRetryAfter(now.Add(10*time.Second).Format(http.TimeFormat), now))
+}
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+)
+
+func (client *RestClient) GetMytfInfo(bizid string) (model.MytfInfo, error) {
+	return client.GetMytfInfoWithContext(context.Background(), bizid)
+}
+
+// GetMytfInfoWithContext queries the trusted execution environment of the chain.
+func (client *RestClient) GetMytfInfoWithContext(ctx context.Context, bizid string) (model.MytfInfo, error) {
+	info := model.MytfInfo{}
+	err := client.chainCallForBizResult(ctx, model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId: client.RestClientProperties.AccessId,
+			BizId:    bizid,
+			Method:   model.GETMYTFINFO,
+		},
+	}, &info)
+	return info, err
+}
+
+func (client *RestClient) GetTappInfo(bizid string, request model.TappQueryRequest) (model.TappInfo, error) {
+	return client.GetTappInfoWithContext(context.Background(), bizid, request)
+}
+
+func (client *RestClient) GetTappInfoWithContext(ctx context.Context, bizid string, request model.TappQueryRequest) (model.TappInfo, error) {
+	if request.TappId == "" {
+		return model.TappInfo{}, response.NewValidationError(fmt.Sprintf("%v method must has tappId", model.GETTAPPINFO))
+	}
+	requestStr, err := json.Marshal(request)
+	if err != nil {
+		return model.TappInfo{}, err
+	}
+	info := model.TappInfo{}
+	err = client.chainCallForBizResult(ctx, model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId:   client.RestClientProperties.AccessId,
+			BizId:      bizid,
+			Method:     model.GETTAPPINFO,
+			RequestStr: string(requestStr),
+		},
+	}, &info)
+	return info, err
+}
+
+func (client *RestClient) InstallTapp(bizid, orderId, account, tenantId, kmsId string, request model.TappInstallRequest, gas int64) (response.BaseResp, error) {
+	return client.InstallTappWithContext(context.Background(), bizid, orderId, account, tenantId, kmsId, request, gas)
+}
+
+// InstallTappWithContext installs a version of a tapp, Data holds the transaction hash.
+func (client *RestClient) InstallTappWithContext(ctx context.Context, bizid, orderId, account, tenantId, kmsId string, request model.TappInstallRequest, gas int64) (response.BaseResp, error) {
+	if request.TappId == "" {
+		return response.BaseResp{}, response.NewValidationError(fmt.Sprintf("%v method must has tappId", model.INSTALLTAPP))
+	}
+	if request.Code == "" {
+		return response.BaseResp{}, response.NewValidationError(fmt.Sprintf("%v method must has code", model.INSTALLTAPP))
+	}
+	callRestBizParam, err := client.tappParam(model.INSTALLTAPP, bizid, orderId, account, tenantId, kmsId, request, gas)
+	if err != nil {
+		return response.BaseResp{}, err
+	}
+	return client.ChainCallForBizWithContext(ctx, callRestBizParam)
+}
+
+func (client *RestClient) ExecuteTapp(bizid, orderId, account, tenantId, kmsId string, request model.TappExecuteRequest, gas int64) (model.TappResult, error) {
+	return client.ExecuteTappWithContext(context.Background(), bizid, orderId, account, tenantId, kmsId, request, gas)
+}
+
+// ExecuteTappWithContext executes request.Method of a tapp, the request and the output are recorded on
+// the chain.
+func (client *RestClient) ExecuteTappWithContext(ctx context.Context, bizid, orderId, account, tenantId, kmsId string, request model.TappExecuteRequest, gas int64) (model.TappResult, error) {
+	return client.executeTapp(ctx, model.EXECUTETAPP, bizid, orderId, account, tenantId, kmsId, request, gas)
+}
+
+func (client *RestClient) ExecuteTappPrivate(bizid, orderId, account, tenantId, kmsId string, request model.TappExecuteRequest, gas int64) (model.TappResult, error) {
+	return client.ExecuteTappPrivateWithContext(context.Background(), bizid, orderId, account, tenantId, kmsId, request, gas)
+}
+
+// ExecuteTappPrivateWithContext executes request.Method of a tapp inside the enclave, only the digest
+// of the request and the output is recorded on the chain.
+func (client *RestClient) ExecuteTappPrivateWithContext(ctx context.Context, bizid, orderId, account, tenantId, kmsId string, request model.TappExecuteRequest, gas int64) (model.TappResult, error) {
+	return client.executeTapp(ctx, model.EXECUTETAPPPRIVATE, bizid, orderId, account, tenantId, kmsId, request, gas)
+}
+
+func (client *RestClient) executeTapp(ctx context.Context, method model.Method, bizid, orderId, account, tenantId, kmsId string, request model.TappExecuteRequest, gas int64) (model.TappResult, error) {
+	if request.TappId == "" {
+		return model.TappResult{}, response.NewValidationError(fmt.Sprintf("%v method must has tappId", method))
+	}
+	if request.Method == "" {
+		return model.TappResult{}, response.NewValidationError(fmt.Sprintf("%v method must has tapp method", method))
+	}
+	callRestBizParam, err := client.tappParam(method, bizid, orderId, account, tenantId, kmsId, request, gas)
+	if err != nil {
+		return model.TappResult{}, err
+	}
+	result := model.TappResult{}
+	err = client.chainCallForBizResult(ctx, callRestBizParam, &result)
+	return result, err
+}
+
+func (client *RestClient) tappParam(method model.Method, bizid, orderId, account, tenantId, kmsId string, request interface{}, gas int64) (model.CallRestBizParam, error) {
+	requestStr, err := json.Marshal(request)
+	if err != nil {
+		return model.CallRestBizParam{}, err
+	}
+	return model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId:   client.RestClientProperties.AccessId,
+			BizId:      bizid,
+			Method:     method,
+			RequestStr: string(requestStr),
+		},
+		OrderId:    orderId,
+		Account:    account,
+		TenantId:   tenantId,
+		MykmsKeyId: kmsId,
+		Gas:        gas, // 0表示不受限
+	}, nil
+}
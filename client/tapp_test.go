@@ -0,0 +1,75 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTapp(t *testing.T) {
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	info, err := restClient.GetMytfInfo(RestBizTestBizID)
+	require.NoError(t, err)
+	require.NotEmpty(t, info.PublicKey)
+
+	tappId := fmt.Sprintf("test_tapp_%v", uuid.New().String())
+	server.HandleContract(tappId, func(param model.CallRestBizParam) ([]interface{}, []byte, error) {
+		request := model.TappExecuteRequest{}
+		if err := json.Unmarshal([]byte(param.RequestStr), &request); err != nil {
+			return nil, nil, err
+		}
+		return []interface{}{request.Method, request.Params}, nil, nil
+	})
+	_, err = restClient.GetTappInfo(RestBizTestBizID, model.TappQueryRequest{TappId: tappId})
+	require.Truef(t, errors.Is(err, response.ErrNotFound), "expect not installed err:%+v", err)
+
+	for version := int64(1); version <= 2; version++ {
+		orderId := fmt.Sprintf("order_%v", uuid.New().String())
+		request := model.TappInstallRequest{TappId: tappId, Version: version, Code: "dGFwcA==", Description: fmt.Sprintf("v%v", version)}
+		baseResp, err := restClient.InstallTapp(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, request, 50000)
+		require.Truef(t, err == nil && baseResp.Success && baseResp.Code == "200", "no succ resp baseResp:%+v err:%+v", baseResp, err)
+	}
+	tappInfo, err := restClient.GetTappInfo(RestBizTestBizID, model.TappQueryRequest{TappId: tappId})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), tappInfo.Version)
+	require.Equal(t, "v2", tappInfo.Description)
+	tappInfo, err = restClient.GetTappInfo(RestBizTestBizID, model.TappQueryRequest{TappId: tappId, Version: 1})
+	require.NoError(t, err)
+	require.Equal(t, "v1", tappInfo.Description)
+
+	request := model.TappExecuteRequest{TappId: tappId, Method: "score", Params: `{"user":"alice"}`}
+	orderId := fmt.Sprintf("order_%v", uuid.New().String())
+	result, err := restClient.ExecuteTapp(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, request, 50000)
+	require.NoError(t, err)
+	require.JSONEq(t, `["score","{\"user\":\"alice\"}"]`, result.Output)
+	tx, ok := server.Transaction(result.Hash)
+	require.True(t, ok)
+	require.Equal(t, model.Method(model.EXECUTETAPP), tx.Param.Method)
+
+	orderId = fmt.Sprintf("order_%v", uuid.New().String())
+	result, err = restClient.ExecuteTappPrivate(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, request, 50000)
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Hash)
+	require.Equal(t, 1, server.Calls(model.EXECUTETAPPPRIVATE))
+}
+
+func TestTapp_Validation(t *testing.T) {
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	orderId := fmt.Sprintf("order_%v", uuid.New().String())
+	_, err := restClient.GetTappInfo(RestBizTestBizID, model.TappQueryRequest{})
+	require.True(t, errors.Is(err, response.ErrValidation))
+	_, err = restClient.InstallTapp(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, model.TappInstallRequest{TappId: "tapp"}, 0)
+	require.True(t, errors.Is(err, response.ErrValidation))
+	_, err = restClient.ExecuteTapp(RestBizTestBizID, orderId, RestBizTestAccount, RestBizTestTenantID, RestBizTestKmsID, model.TappExecuteRequest{TappId: "tapp"}, 0)
+	require.True(t, errors.Is(err, response.ErrValidation))
+	_, err = restClient.ExecuteTappPrivate(RestBizTestBizID, orderId, "", RestBizTestTenantID, RestBizTestKmsID, model.TappExecuteRequest{TappId: "tapp", Method: "m"}, 0)
+	require.True(t, errors.Is(err, response.ErrValidation))
+	require.Zero(t, server.Calls(model.EXECUTETAPPPRIVATE))
+}
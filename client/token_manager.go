@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// tokenRefreshRatio is the share of the token ttl after which the token is refreshed proactively.
+const tokenRefreshRatio = 0.9
+
+// shakeCall is an in-flight shake hand shared by every goroutine asking for a new token.
+type shakeCall struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+// tokenManager hands out the rest token and collapses concurrent re-handshakes into one.
+type tokenManager struct {
+	shake func(ctx context.Context) (string, error)
+	clock Clock
+	ttl   time.Duration
+
+	mu       sync.Mutex
+	token    string
+	issuedAt time.Time
+	inflight *shakeCall
+}
+
+func newTokenManager(shake func(ctx context.Context) (string, error), clock Clock, ttl time.Duration) *tokenManager {
+	return &tokenManager{shake: shake, clock: clock, ttl: ttl}
+}
+
+// current returns the token without refreshing it.
+func (manager *tokenManager) current() string {
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+	return manager.token
+}
+
+// Token returns the current token, shaking hand first when there is none yet or it is about to expire.
+func (manager *tokenManager) Token(ctx context.Context) (string, error) {
+	manager.mu.Lock()
+	token := manager.token
+	stale := token == "" || (manager.ttl > 0 && manager.clock.Now().Sub(manager.issuedAt) >= time.Duration(float64(manager.ttl)*tokenRefreshRatio))
+	manager.mu.Unlock()
+	if !stale {
+		return token, nil
+	}
+	return manager.Refresh(ctx, token)
+}
+
+// Refresh replaces stale with a new token. If another goroutine already replaced stale its token is
+// returned, and if a shake hand is in flight the caller waits for it instead of starting another one.
+func (manager *tokenManager) Refresh(ctx context.Context, stale string) (string, error) {
+	for {
+		manager.mu.Lock()
+		if manager.token != stale {
+			token := manager.token
+			manager.mu.Unlock()
+			return token, nil
+		}
+		call := manager.inflight
+		leader := call == nil
+		if leader {
+			call = &shakeCall{done: make(chan struct{})}
+			manager.inflight = call
+		}
+		manager.mu.Unlock()
+
+		if leader {
+			call.token, call.err = manager.shake(ctx)
+			manager.mu.Lock()
+			if call.err == nil {
+				manager.token = call.token
+				manager.issuedAt = manager.clock.Now()
+			}
+			manager.inflight = nil
+			manager.mu.Unlock()
+			close(call.done)
+			return call.token, call.err
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-call.done:
+		}
+		if call.err == nil {
+			return call.token, nil
+		}
+		if ctx.Err() == nil && (errors.Is(call.err, context.Canceled) || errors.Is(call.err, context.DeadlineExceeded)) {
+			// the leader gave up on its own context, try again with ours
+			continue
+		}
+		return "", call.err
+	}
+}
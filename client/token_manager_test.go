@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (clock *fakeClock) Now() time.Time {
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+	return clock.now
+}
+
+func (clock *fakeClock) Advance(d time.Duration) {
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+	clock.now = clock.now.Add(d)
+}
+
+func TestTokenManager_RefreshSingleFlight(t *testing.T) {
+	var shakes int32
+	release := make(chan struct{})
+	manager := newTokenManager(func(ctx context.Context) (string, error) {
+		n := atomic.AddInt32(&shakes, 1)
+		<-release
+		return fmt.Sprintf("token%v", n), nil
+	}, systemClock{}, 0)
+
+	var wg sync.WaitGroup
+	tokens := make(chan string, 20)
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := manager.Refresh(context.Background(), "")
+			tokens <- token
+			errs <- err
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(tokens)
+	close(errs)
+	for err := range errs {
+		require.Nil(t, err)
+	}
+	for token := range tokens {
+		require.Equal(t, "token1", token)
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&shakes))
+
+	// a caller holding an already replaced token gets the current one without another shake hand
+	token, err := manager.Refresh(context.Background(), "token0")
+	require.Nil(t, err)
+	require.Equal(t, "token1", token)
+	require.Equal(t, int32(1), atomic.LoadInt32(&shakes))
+}
+
+func TestTokenManager_ProactiveRefresh(t *testing.T) {
+	var shakes int32
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	manager := newTokenManager(func(ctx context.Context) (string, error) {
+		return fmt.Sprintf("token%v", atomic.AddInt32(&shakes, 1)), nil
+	}, clock, 10*time.Second)
+
+	token, err := manager.Token(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, "token1", token)
+	clock.Advance(8 * time.Second)
+	token, _ = manager.Token(context.Background())
+	require.Equal(t, "token1", token)
+	clock.Advance(time.Second)
+	token, _ = manager.Token(context.Background())
+	require.Equal(t, "token2", token)
+}
+
+func TestTokenManager_WaiterContextCanceled(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	manager := newTokenManager(func(ctx context.Context) (string, error) {
+		<-release
+		return "token", nil
+	}, systemClock{}, 0)
+	go manager.Refresh(context.Background(), "")
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := manager.Refresh(ctx, "")
+	require.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestTokenManager_LeaderContextCanceled(t *testing.T) {
+	var shakes int32
+	shaking := make(chan struct{})
+	manager := newTokenManager(func(ctx context.Context) (string, error) {
+		n := atomic.AddInt32(&shakes, 1)
+		if n == 1 {
+			close(shaking)
+			<-ctx.Done()
+			// http.Client.Do wraps the context error
+			return "", &url.Error{Op: "Post", URL: "http://rest/api/contract/shakeHand", Err: ctx.Err()}
+		}
+		return fmt.Sprintf("token%v", n), nil
+	}, systemClock{}, 0)
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := manager.Refresh(leaderCtx, "")
+		leaderErr <- err
+	}()
+	<-shaking
+	type result struct {
+		token string
+		err   error
+	}
+	follower := make(chan result, 1)
+	go func() {
+		token, err := manager.Refresh(context.Background(), "")
+		follower <- result{token, err}
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	require.True(t, errors.Is(<-leaderErr, context.Canceled))
+	got := <-follower
+	require.Nil(t, got.err)
+	require.Equal(t, "token2", got.token)
+	require.Equal(t, int32(2), atomic.LoadInt32(&shakes))
+}
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/response"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+var (
+	DefaultWaitTimeout        = 30000
+	DefaultWaitPollMultiplier = 1.5
+)
+
+// ErrWaitTimeout is returned when a transaction is still pending at the end of the wait.
+var ErrWaitTimeout = errors.New("wait for transaction timeout")
+
+// WaitState is the state of the waited transaction after the last poll.
+type WaitState string
+
+const (
+	WaitStateDone           WaitState = "DONE"
+	WaitStateFailed         WaitState = "FAILED"
+	WaitStateNotFound       WaitState = "NOT_FOUND"
+	WaitStateWaitingVerify  WaitState = "WAITING_VERIFY"
+	WaitStateWaitingExecute WaitState = "WAITING_EXECUTE"
+)
+
+// WaitOptions controls the polling of WaitForReceipt, WaitForTransaction and WaitForChainReady, zero
+// fields take the defaults: PollInterval is BackOffPeriod, MaxPollInterval is DefaultMaxBackOffPeriod,
+// Multiplier is DefaultWaitPollMultiplier and Timeout is DefaultWaitTimeout. MaxPolls 0 means unlimited.
+type WaitOptions struct {
+	PollInterval    time.Duration
+	MaxPollInterval time.Duration
+	Multiplier      float64
+	Timeout         time.Duration
+	MaxPolls        int
+}
+
+// WaitResult reports the last response of a wait.
+type WaitResult struct {
+	Resp    response.BaseResp
+	State   WaitState
+	Polls   int
+	Elapsed time.Duration
+}
+
+func (client *RestClient) WaitForReceipt(bizid, hash string, opts WaitOptions) (WaitResult, error) {
+	return client.WaitForReceiptWithContext(context.Background(), bizid, hash, opts)
+}
+
+// WaitForReceiptWithContext polls QUERYRECEIPT until the receipt leaves the 404/413/414 states.
+func (client *RestClient) WaitForReceiptWithContext(ctx context.Context, bizid, hash string, opts WaitOptions) (WaitResult, error) {
+	return client.waitFor(ctx, bizid, hash, model.QUERYRECEIPT, opts)
+}
+
+func (client *RestClient) WaitForTransaction(bizid, hash string, opts WaitOptions) (WaitResult, error) {
+	return client.WaitForTransactionWithContext(context.Background(), bizid, hash, opts)
+}
+
+// WaitForTransactionWithContext polls QUERYTRANSACTION until the transaction leaves the 404/413/414 states.
+func (client *RestClient) WaitForTransactionWithContext(ctx context.Context, bizid, hash string, opts WaitOptions) (WaitResult, error) {
+	return client.waitFor(ctx, bizid, hash, model.QUERYTRANSACTION, opts)
+}
+
+func (client *RestClient) waitOptions(opts WaitOptions) WaitOptions {
+	if opts.PollInterval <= 0 {
+		backoffPeriod := DefaultBackOffPeriod
+		if client.RestClientProperties.BackOffPeriod != 0 {
+			backoffPeriod = client.RestClientProperties.BackOffPeriod
+		}
+		opts.PollInterval = time.Duration(backoffPeriod) * time.Millisecond
+	}
+	if opts.MaxPollInterval <= 0 {
+		opts.MaxPollInterval = time.Duration(DefaultMaxBackOffPeriod) * time.Millisecond
+	}
+	if opts.Multiplier < 1 {
+		opts.Multiplier = DefaultWaitPollMultiplier
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = time.Duration(DefaultWaitTimeout) * time.Millisecond
+	}
+	return opts
+}
+
+func (client *RestClient) waitFor(ctx context.Context, bizid, hash string, method model.Method, opts WaitOptions) (WaitResult, error) {
+	opts = client.waitOptions(opts)
+	waitCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	start := client.clock.Now()
+	result := WaitResult{}
+	interval := opts.PollInterval
+	for {
+		baseResp, err := client.ChainCallWithContext(waitCtx, hash, bizid, "", method)
+		result.Polls++
+		result.Elapsed = client.clock.Now().Sub(start)
+		if err != nil {
+			if ctx.Err() == nil && waitCtx.Err() == context.DeadlineExceeded {
+				return result, fmt.Errorf("%w,state:%v polls:%v", ErrWaitTimeout, result.State, result.Polls)
+			}
+			return result, err
+		}
+		result.Resp = baseResp
+		codeErr := baseResp.Err()
+		switch {
+		case codeErr == nil:
+			result.State = WaitStateDone
+			return result, nil
+		case errors.Is(codeErr, response.ErrNotFound):
+			result.State = WaitStateNotFound
+		case errors.Is(codeErr, response.ErrTxWaitingVerify):
+			result.State = WaitStateWaitingVerify
+		case errors.Is(codeErr, response.ErrTxWaitingExecute):
+			result.State = WaitStateWaitingExecute
+		default:
+			result.State = WaitStateFailed
+			return result, nil
+		}
+		if opts.MaxPolls > 0 && result.Polls >= opts.MaxPolls {
+			return result, fmt.Errorf("%w,state:%v polls:%v", ErrWaitTimeout, result.State, result.Polls)
+		}
+
+		client.logger.WithFields(log.Fields{
+			"hash":     hash,
+			"state":    result.State,
+			"polls":    result.Polls,
+			"interval": interval.String(),
+		}).Infof("wait for %v", method)
+		if err := sleepWithContext(waitCtx, interval); err != nil {
+			result.Elapsed = client.clock.Now().Sub(start)
+			if ctx.Err() == nil {
+				return result, fmt.Errorf("%w,state:%v polls:%v", ErrWaitTimeout, result.State, result.Polls)
+			}
+			return result, err
+		}
+		interval = time.Duration(float64(interval) * opts.Multiplier)
+		if interval > opts.MaxPollInterval {
+			interval = opts.MaxPollInterval
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"errors"
+	"github.com/ctwel/antchain-client-go-sdk/client/internal/resttest"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRestClient_WaitForReceipt(t *testing.T) {
+	server, restClient := newTestRestClient(t, resttest.WithPendingStates(3, 2))
+	defer server.Close()
+	baseResp, err := restClient.Deposit(RestBizTestBizID, "order_wait_for_receipt", RestBizTestAccount, RestBizTestTenantID, "content", RestBizTestKmsID, 0)
+	require.Truef(t, err == nil && baseResp.Code == "200", "no succ deposit resp baseResp:%+v err:%+v", baseResp, err)
+
+	result, err := restClient.WaitForReceipt(RestBizTestBizID, baseResp.Data, WaitOptions{PollInterval: time.Millisecond})
+	require.Truef(t, err == nil, "fail to wait for receipt,err:%+v", err)
+	require.Equal(t, WaitStateDone, result.State)
+	require.Equal(t, 6, result.Polls)
+	require.Equal(t, "200", result.Resp.Code)
+	require.True(t, result.Elapsed > 0)
+}
+
+func TestRestClient_WaitForTransaction_Timeout(t *testing.T) {
+	server, restClient := newTestRestClient(t, resttest.WithPendingStates(1000, 0))
+	defer server.Close()
+	baseResp, err := restClient.Deposit(RestBizTestBizID, "order_wait_for_transaction", RestBizTestAccount, RestBizTestTenantID, "content", RestBizTestKmsID, 0)
+	require.Truef(t, err == nil && baseResp.Code == "200", "no succ deposit resp baseResp:%+v err:%+v", baseResp, err)
+
+	result, err := restClient.WaitForTransaction(RestBizTestBizID, baseResp.Data, WaitOptions{PollInterval: 5 * time.Millisecond, Timeout: 100 * time.Millisecond})
+	require.Truef(t, errors.Is(err, ErrWaitTimeout), "expect wait timeout,err:%+v", err)
+	require.Equal(t, WaitStateWaitingVerify, result.State)
+	require.True(t, result.Polls > 1)
+
+	result, err = restClient.WaitForTransaction(RestBizTestBizID, baseResp.Data, WaitOptions{PollInterval: time.Millisecond, MaxPolls: 3})
+	require.Truef(t, errors.Is(err, ErrWaitTimeout), "expect wait timeout,err:%+v", err)
+	require.Equal(t, 3, result.Polls)
+}
+
+func TestRestClient_WaitForReceipt_Failed(t *testing.T) {
+	server, restClient := newTestRestClient(t)
+	defer server.Close()
+	server.InjectFault(resttest.Fault{Method: model.QUERYRECEIPT, Code: "400", Data: "bad hash"})
+	result, err := restClient.WaitForReceipt(RestBizTestBizID, "hash", WaitOptions{PollInterval: time.Millisecond})
+	require.Nil(t, err)
+	require.Equal(t, WaitStateFailed, result.State)
+	require.Equal(t, "400", result.Resp.Code)
+}
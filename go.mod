@@ -1,6 +1,6 @@
 module github.com/ctwel/antchain-client-go-sdk
 
-go 1.12
+go 1.13
 
 require (
 	github.com/btcsuite/btcd v0.20.1-beta
@@ -0,0 +1,35 @@
+package model
+
+// AccessKeyStatus is the status of an access key or a kms key.
+type AccessKeyStatus string
+
+const (
+	AccessKeyEnabled  AccessKeyStatus = "ENABLED"
+	AccessKeyDisabled AccessKeyStatus = "DISABLED"
+)
+
+// AccessKey is an access key returned by APPLYKEY, RESETAPPLYKEY and QUERYACCESSLIST, PublicKey is the
+// pem encoded key requests of AccessId are verified with.
+type AccessKey struct {
+	AccessId   string          `json:"accessId"`
+	TenantId   string          `json:"tenantId"`
+	PublicKey  string          `json:"publicKey,omitempty"`
+	Status     AccessKeyStatus `json:"status"`
+	CreateTime int64           `json:"createTime,omitempty"`
+}
+
+// KmsKey is a key kept by the kms of a tenant, as returned by QUERYTENANTKMSLIST.
+type KmsKey struct {
+	MykmsKeyId string          `json:"mykmsKeyId"`
+	TenantId   string          `json:"tenantId"`
+	Account    string          `json:"account,omitempty"`
+	Status     AccessKeyStatus `json:"status"`
+	CreateTime int64           `json:"createTime,omitempty"`
+}
+
+// SignHashResult is the signature of a hash made by SIGNHASH with the kms key MykmsKeyId, Signature is
+// hex encoded.
+type SignHashResult struct {
+	MykmsKeyId string `json:"mykmsKeyId"`
+	Signature  string `json:"signature"`
+}
@@ -9,3 +9,8 @@ type BaseParam struct {
 	Method     Method `json:"method,omitempty"`
 	SecretKey  string `json:"secretKey,omitempty"`
 }
+
+// SetToken stamps the rest token, params embedding BaseParam get it promoted.
+func (param *BaseParam) SetToken(token string) {
+	param.Token = token
+}
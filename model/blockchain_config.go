@@ -0,0 +1,11 @@
+package model
+
+// BlockchainConfig registers a chain with the rest server through REGISTERBLOCKCHAINCONFIG, Nodes are
+// the host:port addresses of its nodes and the certificates are pem encoded.
+type BlockchainConfig struct {
+	Name       string   `json:"name"`
+	Nodes      []string `json:"nodes"`
+	CaCert     string   `json:"caCert,omitempty"`
+	ClientCert string   `json:"clientCert,omitempty"`
+	ClientKey  string   `json:"clientKey,omitempty"`
+}
@@ -4,6 +4,7 @@ type CallRestBizParam struct {
 	BaseParam
 	OrderId            string     `json:"orderId,omitempty"`
 	Account            string     `json:"account,omitempty"`
+	ToAccount          string     `json:"toAccount,omitempty"`
 	Content            string     `json:"content,omitempty"`
 	TenantId           string     `json:"tenantid,omitempty"`
 	Uid                string     `json:"uid,omitempty"`
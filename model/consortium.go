@@ -0,0 +1,34 @@
+package model
+
+// InviteUserRequest invites the tenant TenantId into the consortium of a chain through INVITEUSER.
+type InviteUserRequest struct {
+	TenantId string `json:"tenantId"`
+	Role     string `json:"role,omitempty"`
+}
+
+// Invitation is the invitation returned by INVITEUSER, Status is INVITED until the tenant accepts it.
+type Invitation struct {
+	InviteId   string `json:"inviteId"`
+	BizId      string `json:"bizid"`
+	TenantId   string `json:"tenantId"`
+	Role       string `json:"role,omitempty"`
+	Status     string `json:"status"`
+	CreateTime int64  `json:"createTime,omitempty"`
+}
+
+// NewChainRequest provisions a chain of NodeCount nodes through NEWCHAIN, Members are the tenant ids
+// joining its consortium besides the caller.
+type NewChainRequest struct {
+	Name      string   `json:"name"`
+	NodeCount int      `json:"nodeCount,omitempty"`
+	Consensus string   `json:"consensus,omitempty"`
+	Members   []string `json:"members,omitempty"`
+}
+
+// Chain is the chain returned by NEWCHAIN, requests to it are sent with BizId once it is ready.
+type Chain struct {
+	BizId      string `json:"bizid"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	CreateTime int64  `json:"createTime,omitempty"`
+}
@@ -3,6 +3,8 @@ package model
 type ErrorCode string
 
 const (
+	ServiceSuccess          = "200"
+	ServiceTokenExpired     = "202"
 	ServiceQueryNoResult    = "404"
 	ServiceTxWaitingVerify  = "413"
 	ServiceTxWaitingExecute = "414"
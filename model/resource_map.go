@@ -0,0 +1,13 @@
+package model
+
+// ResourceMap is a named key value map kept by the BaaS for a biz, it is the result of GETRESOURCEMAP
+// and the requestStr of SETRESOURCEMAP and UPDATERESOURCEMAP.
+type ResourceMap struct {
+	Name    string            `json:"name"`
+	Entries map[string]string `json:"entries"`
+}
+
+// ResourceMapRequest is the requestStr of GETRESOURCEMAP.
+type ResourceMapRequest struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,48 @@
+package model
+
+// TappQueryRequest is the requestStr of GETTAPPINFO, Version 0 means the latest version.
+type TappQueryRequest struct {
+	TappId  string `json:"tappId"`
+	Version int64  `json:"version,omitempty"`
+}
+
+// TappInstallRequest is the requestStr of INSTALLTAPP, Code is the base64 encoded package of the tapp.
+type TappInstallRequest struct {
+	TappId      string `json:"tappId"`
+	Version     int64  `json:"version"`
+	Code        string `json:"code"`
+	Description string `json:"description,omitempty"`
+}
+
+// TappExecuteRequest is the requestStr of EXECUTETAPP and EXECUTETAPPPRIVATE, Params is passed to
+// Method as is, Version 0 means the latest version.
+type TappExecuteRequest struct {
+	TappId  string `json:"tappId"`
+	Version int64  `json:"version,omitempty"`
+	Method  string `json:"method"`
+	Params  string `json:"params,omitempty"`
+}
+
+// MytfInfo describes the trusted execution environment of a chain, PublicKey is the hex encoded key of
+// the enclave and Measurement the hex encoded measurement of its code.
+type MytfInfo struct {
+	PublicKey   string `json:"publicKey"`
+	Measurement string `json:"measurement,omitempty"`
+	Version     string `json:"version,omitempty"`
+}
+
+// TappInfo describes an installed tapp, Owner is the hex encoded identity of the installing account.
+type TappInfo struct {
+	TappId      string `json:"tappId"`
+	Version     int64  `json:"version"`
+	Owner       string `json:"owner,omitempty"`
+	CodeHash    string `json:"codeHash,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// TappResult is the result of EXECUTETAPP and EXECUTETAPPPRIVATE, Output is the value returned by the
+// tapp method.
+type TappResult struct {
+	Hash   string `json:"hash"`
+	Output string `json:"output,omitempty"`
+}
@@ -0,0 +1,29 @@
+package mychain
+
+// AccountStatus is the status of a mychain account.
+type AccountStatus int
+
+const (
+	AccountNormal     AccountStatus = 0
+	AccountFreeze     AccountStatus = 1
+	AccountRecovering AccountStatus = 2
+)
+
+func (status AccountStatus) String() string {
+	switch status {
+	case AccountNormal:
+		return "NORMAL"
+	case AccountFreeze:
+		return "FREEZE"
+	case AccountRecovering:
+		return "RECOVERING"
+	}
+	return "UNKNOWN"
+}
+
+// Account is the account returned by QUERYACCOUNT, Id is hex encoded.
+type Account struct {
+	Id      string        `json:"id,omitempty"`
+	Balance int64         `json:"balance,omitempty"`
+	Status  AccountStatus `json:"status"`
+}
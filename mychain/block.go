@@ -0,0 +1,26 @@
+package mychain
+
+// BlockHeader is the header of a mychain block, hashes and roots are hex encoded and Timestamp is in
+// milliseconds.
+type BlockHeader struct {
+	Number          int64  `json:"number"`
+	Hash            string `json:"hash,omitempty"`
+	ParentHash      string `json:"parentHash,omitempty"`
+	Timestamp       int64  `json:"timestamp,omitempty"`
+	TransactionRoot string `json:"transactionRoot,omitempty"`
+	ReceiptRoot     string `json:"receiptRoot,omitempty"`
+	StateRoot       string `json:"stateRoot,omitempty"`
+	GasUsed         int64  `json:"gasUsed,omitempty"`
+	Version         int64  `json:"version,omitempty"`
+}
+
+// BlockBody holds the transactions of a block and their receipts in the same order.
+type BlockBody struct {
+	TransactionList []Transaction        `json:"transactionList"`
+	ReceiptList     []TransactionReceipt `json:"receiptList"`
+}
+
+type Block struct {
+	Header BlockHeader `json:"blockHeader"`
+	Body   BlockBody   `json:"blockBody"`
+}
@@ -0,0 +1,57 @@
+package mychain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// IdentityLength is the byte length of a mychain identity.
+const IdentityLength = 32
+
+// Identity identifies an account or contract on mychain, it is the sha256 of the account or contract name.
+type Identity [IdentityLength]byte
+
+// NewIdentity returns the identity of the named account or contract.
+func NewIdentity(name string) Identity {
+	return Identity(sha256.Sum256([]byte(name)))
+}
+
+// HexToIdentity parses a hex encoded identity, with or without 0x prefix.
+func HexToIdentity(s string) (Identity, error) {
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		s = s[2:]
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return Identity{}, fmt.Errorf("identity is not hex encoded,identity:%v err:%w", s, err)
+	}
+	if len(b) != IdentityLength {
+		return Identity{}, fmt.Errorf("identity must be %v bytes,actual:%v", IdentityLength, len(b))
+	}
+	identity := Identity{}
+	copy(identity[:], b)
+	return identity, nil
+}
+
+// Hex returns the identity hex encoded without 0x prefix.
+func (identity Identity) Hex() string {
+	return hex.EncodeToString(identity[:])
+}
+
+func (identity Identity) String() string {
+	return identity.Hex()
+}
+
+func (identity Identity) MarshalText() ([]byte, error) {
+	return []byte(identity.Hex()), nil
+}
+
+func (identity *Identity) UnmarshalText(text []byte) error {
+	parsed, err := HexToIdentity(string(text))
+	if err != nil {
+		return err
+	}
+	*identity = parsed
+	return nil
+}
@@ -0,0 +1,16 @@
+package mychain
+
+// Transaction is a transaction of a block body, From and To are hex encoded identities, Data is base64
+// encoded and Signatures are the hex encoded signatures of its senders. BlockNumber is only set when
+// the transaction is queried on its own.
+type Transaction struct {
+	Hash        string   `json:"hash,omitempty"`
+	BlockNumber int64    `json:"blockNumber,omitempty"`
+	From        string   `json:"from,omitempty"`
+	To          string   `json:"to,omitempty"`
+	Nonce       uint64   `json:"nonce,omitempty"`
+	Timestamp   int64    `json:"timestamp,omitempty"`
+	Value       int64    `json:"value,omitempty"`
+	Data        string   `json:"data,omitempty"`
+	Signatures  []string `json:"signatureList,omitempty"`
+}
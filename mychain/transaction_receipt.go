@@ -1,7 +1,22 @@
 package mychain
 
+// TransactionReceipt is the receipt of the transaction Hash, the TransactionIndex-th of block
+// BlockNumber. A non zero Result means the transaction failed, Output is base64 encoded.
 type TransactionReceipt struct {
-	Result  int64  `json:"result,omitempty"`
-	GasUsed int64  `json:"gasUsed,omitempty"`
-	Output  string `json"output,omitempty"`
+	Hash             string `json:"hash,omitempty"`
+	BlockNumber      int64  `json:"blockNumber,omitempty"`
+	TransactionIndex int64  `json:"transactionIndex,omitempty"`
+	Result           int64  `json:"result,omitempty"`
+	GasUsed          int64  `json:"gasUsed,omitempty"`
+	Output           string `json:"output,omitempty"`
+	Logs             []Log  `json:"logs,omitempty"`
+}
+
+// Log is an event emitted by a contract, From is the hex encoded identity of the sender, To the one of
+// the contract and Data is base64 encoded.
+type Log struct {
+	From   string   `json:"from,omitempty"`
+	To     string   `json:"to,omitempty"`
+	Topics []string `json:"topics,omitempty"`
+	Data   string   `json:"logData,omitempty"`
 }
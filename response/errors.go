@@ -0,0 +1,89 @@
+package response
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"strings"
+)
+
+// Sentinel errors for the BaaS result codes, match them with errors.Is.
+var (
+	ErrTokenExpired       = errors.New("rest token expired")
+	ErrNotFound           = errors.New("query no result")
+	ErrTxWaitingVerify    = errors.New("transaction waiting verify")
+	ErrTxWaitingExecute   = errors.New("transaction waiting execute")
+	ErrServer             = errors.New("rest server error")
+	ErrValidation         = errors.New("invalid request param")
+	ErrNon2xxStatus       = errors.New("non 2xx http status")
+	ErrUnsuccessfulResult = errors.New("unsuccessful rest result")
+	ErrTxFailed           = errors.New("transaction receipt result is not success")
+)
+
+// Error carries the raw BaaS response of a failed call, use errors.As to get it.
+type Error struct {
+	Code       string
+	Data       string
+	HTTPStatus int
+	kind       error
+}
+
+func (e *Error) Error() string {
+	if e.HTTPStatus != 0 && e.kind == ErrNon2xxStatus {
+		return fmt.Sprintf("%v,statusCode:%v", e.kind, e.HTTPStatus)
+	}
+	if e.Code == "" {
+		return fmt.Sprintf("%v,data:%v", e.kind, e.Data)
+	}
+	return fmt.Sprintf("%v,code:%v data:%v", e.kind, e.Code, e.Data)
+}
+
+// Unwrap returns the sentinel the error is classified as.
+func (e *Error) Unwrap() error {
+	return e.kind
+}
+
+// NewCodeError classifies a BaaS result code, unknown codes unwrap to ErrUnsuccessfulResult.
+func NewCodeError(code, data string, httpStatus int) error {
+	return &Error{Code: code, Data: data, HTTPStatus: httpStatus, kind: codeKind(code)}
+}
+
+// NewValidationError reports a request rejected before it was sent.
+func NewValidationError(data string) error {
+	return &Error{Data: data, kind: ErrValidation}
+}
+
+// NewStatusError reports a non 2xx http response.
+func NewStatusError(httpStatus int, data string) error {
+	return &Error{Data: data, HTTPStatus: httpStatus, kind: ErrNon2xxStatus}
+}
+
+// Err returns nil for a successful response, otherwise the typed error for its code.
+func (baseResp BaseResp) Err() error {
+	if baseResp.Success {
+		return nil
+	}
+	return NewCodeError(baseResp.Code, baseResp.Data, 0)
+}
+
+// IsTxPending reports whether err means the transaction is not yet queryable.
+func IsTxPending(err error) bool {
+	return errors.Is(err, ErrNotFound) || errors.Is(err, ErrTxWaitingVerify) || errors.Is(err, ErrTxWaitingExecute)
+}
+
+func codeKind(code string) error {
+	switch code {
+	case model.ServiceTokenExpired:
+		return ErrTokenExpired
+	case model.ServiceQueryNoResult:
+		return ErrNotFound
+	case model.ServiceTxWaitingVerify:
+		return ErrTxWaitingVerify
+	case model.ServiceTxWaitingExecute:
+		return ErrTxWaitingExecute
+	}
+	if strings.HasPrefix(code, "5") {
+		return ErrServer
+	}
+	return ErrUnsuccessfulResult
+}
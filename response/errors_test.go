@@ -0,0 +1,47 @@
+package response
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestBaseResp_Err(t *testing.T) {
+	require.Nil(t, BaseResp{Success: true, Code: "200"}.Err())
+
+	cases := map[string]error{
+		"202": ErrTokenExpired,
+		"404": ErrNotFound,
+		"413": ErrTxWaitingVerify,
+		"414": ErrTxWaitingExecute,
+		"500": ErrServer,
+		"503": ErrServer,
+		"400": ErrUnsuccessfulResult,
+	}
+	for code, sentinel := range cases {
+		err := BaseResp{Code: code, Data: "data"}.Err()
+		require.Truef(t, errors.Is(err, sentinel), "code:%v err:%+v is not %+v", code, err, sentinel)
+		var restErr *Error
+		require.Truef(t, errors.As(fmt.Errorf("wrapped:%w", err), &restErr), "code:%v err:%+v is not *Error", code, err)
+		require.Equal(t, code, restErr.Code)
+		require.Equal(t, "data", restErr.Data)
+	}
+}
+
+func TestIsTxPending(t *testing.T) {
+	require.True(t, IsTxPending(BaseResp{Code: "413"}.Err()))
+	require.True(t, IsTxPending(BaseResp{Code: "414"}.Err()))
+	require.True(t, IsTxPending(BaseResp{Code: "404"}.Err()))
+	require.False(t, IsTxPending(BaseResp{Code: "500"}.Err()))
+	require.False(t, IsTxPending(nil))
+}
+
+func TestNewStatusError(t *testing.T) {
+	err := NewStatusError(502, "bad gateway")
+	require.True(t, errors.Is(err, ErrNon2xxStatus))
+	var restErr *Error
+	require.True(t, errors.As(err, &restErr))
+	require.Equal(t, 502, restErr.HTTPStatus)
+	require.True(t, errors.Is(NewValidationError("no bizid"), ErrValidation))
+}
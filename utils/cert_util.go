@@ -12,17 +12,26 @@ import (
 )
 
 func Sign(plain, priKey string) (string, error) {
-	h := sha256.New()
-	h.Write([]byte(plain))
-	d := h.Sum(nil)
-
 	privateKey, err := getPrivateKey(priKey)
 	if err != nil {
 		return "", err
 	}
+	sig, err := SignWithKey(plain, privateKey)
+	if err != nil {
+		return "", fmt.Errorf("fail to sign plain:%+v priKey:%+v err:%+v", plain, priKey, err)
+	}
+	return sig, nil
+}
+
+// SignWithKey signs the sha256 digest of plain and returns the hex encoded signature.
+func SignWithKey(plain string, privateKey *rsa.PrivateKey) (string, error) {
+	h := sha256.New()
+	h.Write([]byte(plain))
+	d := h.Sum(nil)
+
 	sig, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, d)
 	if err != nil {
-		return "", fmt.Errorf("fail to SignPKCS1v15 plain:%+v priKey:%+v err:%+v", plain, priKey, err)
+		return "", fmt.Errorf("fail to SignPKCS1v15 plain:%+v err:%+v", plain, err)
 	}
 
 	return fmt.Sprintf("%x", sig), nil
@@ -33,7 +42,12 @@ func getPrivateKey(priKey string) (*rsa.PrivateKey, error) {
 	if err != nil {
 		return nil, fmt.Errorf("fail to read priKey priKey:%+v err:%+v", priKey, err)
 	}
+	return ParsePrivateKey(priv)
+}
 
+// ParsePrivateKey parses a PEM encoded PKCS1 or PKCS8 RSA private key.
+func ParsePrivateKey(priv []byte) (*rsa.PrivateKey, error) {
+	var err error
 	privPem, _ := pem.Decode(priv)
 	if privPem == nil {
 		return nil, fmt.Errorf("RSA private key is illegal,please check key")
@@ -1,12 +1,28 @@
 package utils
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"github.com/stretchr/testify/require"
+	"io/ioutil"
 	"os"
 	"testing"
 )
 
 func TestSign(t *testing.T) {
-	_, err := Sign("hello", os.Getenv("GOPATH") + "/src/github.com/ctwel/antchain-client-go-sdk/test/access.key")
-	require.Truef(t,err == nil,"sign text failed,err:%+v",err)
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	file, err := ioutil.TempFile("", "access-*.key")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	_, err = file.Write(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	_, err = Sign("hello", file.Name())
+	require.Truef(t, err == nil, "sign text failed,err:%+v", err)
 }
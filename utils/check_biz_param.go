@@ -24,14 +24,20 @@ func CheckCallRestBizParams(callRestBizParam model.CallRestBizParam) response.Ba
 		method != model.QUERYACCESSLIST && method != model.RESETAPPLYKEY && method != model.CREATEACCOUNT &&
 		method != model.DEPLOYNATIVECONTRACT && method != model.QUERYACCOUNT && method != model.QUERYRECEIPT &&
 		method != model.QUERYTRANSACTION && method != model.QUERYRECEIPTBIZ && method != model.QUERYTRANSACTIONBIZ &&
-		method != model.FROZENTENANT && method != model.UNFROZENTENANT {
+		method != model.FROZENTENANT && method != model.UNFROZENTENANT && method != model.GETEVENTTOPICBLOCKNUM &&
+		method != model.UPDATEEVENTTOPICBLOCKNUM && method != model.GETMYTFINFO && method != model.GETTAPPINFO &&
+		method != model.GETRESOURCEMAP && method != model.SETRESOURCEMAP && method != model.UPDATERESOURCEMAP &&
+		method != model.REGISTERBLOCKCHAINCONFIG && method != model.INVITEUSER && method != model.NEWCHAIN &&
+		method != model.PARSEOUTPUT {
 		if callRestBizParam.Uid == "" && callRestBizParam.MykmsKeyId == "" {
 			return response.BaseResp{Success: false, Data: "uid or mykmsKeyId must be not null"}
 		}
 	}
 	if method != model.APPLYKEY && method != model.QUERYACCESSLIST && method != model.RESETAPPLYKEY &&
 		method != model.QUERYRECEIPT && method != model.QUERYTRANSACTION && method != model.FROZENTENANT &&
-		method != model.UNFROZENTENANT &&
+		method != model.UNFROZENTENANT && method != model.GETEVENTTOPICBLOCKNUM && method != model.UPDATEEVENTTOPICBLOCKNUM &&
+		method != model.GETMYTFINFO && method != model.GETTAPPINFO && method != model.GETRESOURCEMAP &&
+		method != model.QUERYTENANTKMSLIST && method != model.SIGNHASH && method != model.PARSEOUTPUT &&
 		callRestBizParam.OrderId == "" {
 		passChecked = false
 		data = fmt.Sprintf("%v method must has orderId", callRestBizParam.Method)
@@ -70,24 +76,46 @@ func CheckCallRestBizParams(callRestBizParam model.CallRestBizParam) response.Ba
 			passChecked = false
 			data = fmt.Sprintf("%v method must has inputParamListStr", callRestBizParam.Method)
 		}
-	//case model.CALLNATIVECONTRACTFORBIZASYNC:
-	//case model.CALLNATIVECONTRACTFORBIZ:
-	//	if callRestBizParam.Account == "" {
-	//		passChecked = false
-	//		data = fmt.Sprintf("%v method must has account", callRestBizParam.Method)
-	//	}
-	//	if callRestBizParam.ContractName == "" {
-	//		passChecked = false
-	//		data = fmt.Sprintf("%v method must has contract name", callRestBizParam.Method)
-	//	}
-	//	if callRestBizParam.MethodSignature == "" {
-	//		passChecked = false
-	//		data = fmt.Sprintf("%v method must has methodSignature", callRestBizParam.Method)
-	//	}
-	//	if callRestBizParam.NativeContractData == "" {
-	//		passChecked = false
-	//		data = fmt.Sprintf("%v method must has nativeContractData", callRestBizParam.Method)
-	//	}
+	case model.CALLNATIVECONTRACT:
+		fallthrough
+	case model.CALLNATIVECONTRACTASYNC:
+		fallthrough
+	case model.CALLNATIVECONTRACTFORBIZ:
+		fallthrough
+	case model.CALLNATIVECONTRACTFORBIZASYNC:
+		if callRestBizParam.Account == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has account", callRestBizParam.Method)
+		}
+		if callRestBizParam.ContractName == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has contract name", callRestBizParam.Method)
+		}
+		if callRestBizParam.MethodSignature == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has methodSignature", callRestBizParam.Method)
+		}
+		if callRestBizParam.NativeContractData == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has nativeContractData", callRestBizParam.Method)
+		}
+	case model.DEPLOYNATIVECONTRACT:
+		// without mykmsKeyId the request is signed by the caller and sent as requestStr
+		if callRestBizParam.MykmsKeyId == "" {
+			break
+		}
+		if callRestBizParam.Account == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has account", callRestBizParam.Method)
+		}
+		if callRestBizParam.ContractName == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has contract name", callRestBizParam.Method)
+		}
+		if callRestBizParam.ContractCode == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has contractCode", callRestBizParam.Method)
+		}
 	case model.QUERYRECEIPT:
 		fallthrough
 	case model.QUERYTRANSACTION:
@@ -108,55 +136,164 @@ func CheckCallRestBizParams(callRestBizParam model.CallRestBizParam) response.Ba
 			passChecked = false
 			data = fmt.Sprintf("%v method must has contractCode", callRestBizParam.Method)
 		}
-	//case model.DEPLOYWASMCONTRACT:
-	//	if callRestBizParam.Account == "" {
-	//		passChecked = false
-	//		data = fmt.Sprintf("%v method must has account", callRestBizParam.Method)
-	//	}
-	//	if callRestBizParam.ContractName == "" {
-	//		passChecked = false
-	//		data = fmt.Sprintf("%v method must has contract name", callRestBizParam.Method)
-	//	}
-	//	if callRestBizParam.ContractCode == "" {
-	//		passChecked = false
-	//		data = fmt.Sprintf("%v method must has contractCode", callRestBizParam.Method)
-	//	}
-	//	break
-	//case model.CALLWASMCONTRACT:
-	//case model.CALLWASMCONTRACTASYNC:
-	//	if callRestBizParam.Account == "" {
-	//		passChecked = false
-	//		data = fmt.Sprintf("%v method must has account", callRestBizParam.Method)
-	//	}
-	//	if callRestBizParam.ContractName == "" {
-	//		passChecked = false
-	//		data = fmt.Sprintf("%v method must has contract name", callRestBizParam.Method)
-	//	}
-	//	if callRestBizParam.OutTypes == "" {
-	//		passChecked = false
-	//		data = fmt.Sprintf("%v method must has outTypes", callRestBizParam.Method)
-	//	}
-	//	if callRestBizParam.MethodSignature == "" {
-	//		passChecked = false
-	//		data = fmt.Sprintf("%v method must has methodSignature", callRestBizParam.Method)
-	//	}
-	//	if callRestBizParam.InputParamListStr == "" {
-	//		passChecked = false
-	//		data = fmt.Sprintf("%v method must has inputParamListStr", callRestBizParam.Method)
-	//	}
-	//case model.UPDATECONTRACTFORBIZ:
-	//	if callRestBizParam.Account == "" {
-	//		passChecked = false
-	//		data = fmt.Sprintf("%v method must has account", callRestBizParam.Method)
-	//	}
-	//	if callRestBizParam.ContractName == "" {
-	//		passChecked = false
-	//		data = fmt.Sprintf("%v method must has contract name", callRestBizParam.Method)
-	//	}
-	//	if callRestBizParam.ContractCode == "" {
-	//		passChecked = false
-	//		data = fmt.Sprintf("%v method must has contractCode", callRestBizParam.Method)
-	//	}
+	case model.DEPLOYWASMCONTRACT:
+		if callRestBizParam.Account == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has account", callRestBizParam.Method)
+		}
+		if callRestBizParam.ContractName == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has contract name", callRestBizParam.Method)
+		}
+		if callRestBizParam.ContractCode == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has contractCode", callRestBizParam.Method)
+		}
+	case model.CALLWASMCONTRACT:
+		fallthrough
+	case model.CALLWASMCONTRACTASYNC:
+		if callRestBizParam.Account == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has account", callRestBizParam.Method)
+		}
+		if callRestBizParam.ContractName == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has contract name", callRestBizParam.Method)
+		}
+		if callRestBizParam.OutTypes == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has outTypes", callRestBizParam.Method)
+		}
+		if callRestBizParam.MethodSignature == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has methodSignature", callRestBizParam.Method)
+		}
+		if callRestBizParam.InputParamListStr == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has inputParamListStr", callRestBizParam.Method)
+		}
+	case model.UPDATECONTRACT:
+		fallthrough
+	case model.UPDATECONTRACTFORBIZ:
+		if callRestBizParam.Account == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has account", callRestBizParam.Method)
+		}
+		if callRestBizParam.ContractName == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has contract name", callRestBizParam.Method)
+		}
+		if callRestBizParam.ContractCode == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has contractCode", callRestBizParam.Method)
+		}
+	case model.FREEZEACCOUNTASYN:
+		fallthrough
+	case model.UNFREEZEACCOUNTASYN:
+		if callRestBizParam.Account == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has account", callRestBizParam.Method)
+		}
+		if callRestBizParam.ToAccount == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has toAccount", callRestBizParam.Method)
+		}
+		if callRestBizParam.MykmsKeyId == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has mykmsKeyId", callRestBizParam.Method)
+		}
+	case model.GETEVENTTOPICBLOCKNUM:
+		fallthrough
+	case model.UPDATEEVENTTOPICBLOCKNUM:
+		if callRestBizParam.Content == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has topic in content", callRestBizParam.Method)
+		}
+	case model.GETTAPPINFO:
+		if callRestBizParam.RequestStr == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has requestStr", callRestBizParam.Method)
+		}
+	case model.INSTALLTAPP:
+		fallthrough
+	case model.EXECUTETAPP:
+		fallthrough
+	case model.EXECUTETAPPPRIVATE:
+		if callRestBizParam.Account == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has account", callRestBizParam.Method)
+		}
+		if callRestBizParam.RequestStr == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has requestStr", callRestBizParam.Method)
+		}
+	case model.GETRESOURCEMAP:
+		fallthrough
+	case model.SETRESOURCEMAP:
+		fallthrough
+	case model.UPDATERESOURCEMAP:
+		if callRestBizParam.RequestStr == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has requestStr", callRestBizParam.Method)
+		}
+	case model.APPLYKEY:
+		if callRestBizParam.ApplyAccessKey == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has applyAccessKey", callRestBizParam.Method)
+		}
+	case model.RESETAPPLYKEY:
+		if callRestBizParam.ApplyAccessKey == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has applyAccessKey", callRestBizParam.Method)
+		}
+		if callRestBizParam.RequestStr == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has accessId in requestStr", callRestBizParam.Method)
+		}
+	case model.QUERYTENANTKMSLIST:
+		if callRestBizParam.TenantId == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has tenantid", callRestBizParam.Method)
+		}
+	case model.DEPOSITWITHADMIN:
+		if callRestBizParam.Content == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has content", callRestBizParam.Method)
+		}
+	case model.FROZENTENANT:
+		fallthrough
+	case model.UNFROZENTENANT:
+		if callRestBizParam.TenantId == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has tenantid", callRestBizParam.Method)
+		}
+	case model.INVITEUSER:
+		fallthrough
+	case model.NEWCHAIN:
+		fallthrough
+	case model.REGISTERBLOCKCHAINCONFIG:
+		if callRestBizParam.RequestStr == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has requestStr", callRestBizParam.Method)
+		}
+	case model.SIGNHASH:
+		if callRestBizParam.MykmsKeyId == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has mykmsKeyId", callRestBizParam.Method)
+		}
+		if callRestBizParam.RequestStr == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has hash in requestStr", callRestBizParam.Method)
+		}
+	case model.PARSEOUTPUT:
+		if callRestBizParam.OutTypes == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has outTypes", callRestBizParam.Method)
+		}
+		if callRestBizParam.RequestStr == "" {
+			passChecked = false
+			data = fmt.Sprintf("%v method must has output in requestStr", callRestBizParam.Method)
+		}
 	case model.CREATEACCOUNT:
 		if callRestBizParam.Account == "" {
 			passChecked = false
@@ -266,3 +266,264 @@ func TestCheckCallRestBizParams_CreateAccountWithoutMykmsId(t *testing.T) {
 	resp := CheckCallRestBizParams(callRestBizParam)
 	require.Truef(t, !resp.Success, "cannot check create account without kmsid")
 }
+
+func TestCheckCallRestBizParams_DeployWasmContractWithoutContractCode(t *testing.T) {
+	callRestBizParam := model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId: "accessId",
+			BizId:    "bizid",
+			Token:    "token",
+			Method:   model.DEPLOYWASMCONTRACT,
+		},
+		OrderId:      "orderId",
+		MykmsKeyId:   "kmsId",
+		Account:      "account",
+		ContractName: "contractName",
+	}
+	resp := CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, !resp.Success, "cannot check deploy wasm contract without out contract code")
+}
+
+func TestCheckCallRestBizParams_CallWasmContractWithoutOutTypes(t *testing.T) {
+	for _, method := range []model.Method{model.CALLWASMCONTRACT, model.CALLWASMCONTRACTASYNC} {
+		callRestBizParam := model.CallRestBizParam{
+			BaseParam: model.BaseParam{
+				AccessId: "accessId",
+				BizId:    "bizid",
+				Token:    "token",
+				Method:   method,
+			},
+			OrderId:           "orderId",
+			MykmsKeyId:        "kmsId",
+			Account:           "account",
+			ContractName:      "contractName",
+			MethodSignature:   "add(int32)",
+			InputParamListStr: "[1]",
+		}
+		resp := CheckCallRestBizParams(callRestBizParam)
+		require.Truef(t, !resp.Success, "cannot check %v without out types", method)
+	}
+}
+
+func TestCheckCallRestBizParams_CallNativeContractWithoutNativeContractData(t *testing.T) {
+	methods := []model.Method{model.CALLNATIVECONTRACT, model.CALLNATIVECONTRACTASYNC, model.CALLNATIVECONTRACTFORBIZ, model.CALLNATIVECONTRACTFORBIZASYNC}
+	for _, method := range methods {
+		callRestBizParam := model.CallRestBizParam{
+			BaseParam: model.BaseParam{
+				AccessId: "accessId",
+				BizId:    "bizid",
+				Token:    "token",
+				Method:   method,
+			},
+			OrderId:         "orderId",
+			MykmsKeyId:      "kmsId",
+			Account:         "account",
+			ContractName:    "contractName",
+			MethodSignature: "transfer",
+		}
+		resp := CheckCallRestBizParams(callRestBizParam)
+		require.Truef(t, !resp.Success, "cannot check %v without native contract data", method)
+	}
+}
+
+func TestCheckCallRestBizParams_DeployNativeContractWithoutContractCode(t *testing.T) {
+	callRestBizParam := model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId: "accessId",
+			BizId:    "bizid",
+			Token:    "token",
+			Method:   model.DEPLOYNATIVECONTRACT,
+		},
+		OrderId:      "orderId",
+		MykmsKeyId:   "kmsId",
+		Account:      "account",
+		ContractName: "contractName",
+	}
+	resp := CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, !resp.Success, "cannot check deploy native contract without contract code")
+
+	callRestBizParam.MykmsKeyId = ""
+	resp = CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, resp.Success, "deploy native contract signed by the caller needs no contract code resp:%+v", resp)
+}
+
+func TestCheckCallRestBizParams_UpdateContractWithoutContractCode(t *testing.T) {
+	for _, method := range []model.Method{model.UPDATECONTRACT, model.UPDATECONTRACTFORBIZ} {
+		callRestBizParam := model.CallRestBizParam{
+			BaseParam: model.BaseParam{
+				AccessId: "accessId",
+				BizId:    "bizid",
+				Token:    "token",
+				Method:   method,
+			},
+			OrderId:      "orderId",
+			MykmsKeyId:   "kmsId",
+			Account:      "account",
+			ContractName: "contractName",
+		}
+		resp := CheckCallRestBizParams(callRestBizParam)
+		require.Truef(t, !resp.Success, "cannot check %v without contract code", method)
+	}
+}
+
+func TestCheckCallRestBizParams_FreezeAccountWithoutToAccount(t *testing.T) {
+	for _, method := range []model.Method{model.FREEZEACCOUNTASYN, model.UNFREEZEACCOUNTASYN} {
+		callRestBizParam := model.CallRestBizParam{
+			BaseParam: model.BaseParam{
+				AccessId: "accessId",
+				BizId:    "bizid",
+				Token:    "token",
+				Method:   method,
+			},
+			OrderId:    "orderId",
+			MykmsKeyId: "kmsId",
+			Account:    "account",
+		}
+		resp := CheckCallRestBizParams(callRestBizParam)
+		require.Truef(t, !resp.Success, "cannot check %v without toAccount", method)
+	}
+}
+
+func TestCheckCallRestBizParams_EventTopicBlockNum(t *testing.T) {
+	callRestBizParam := model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId: "accessId",
+			BizId:    "bizid",
+			Token:    "token",
+			Method:   model.UPDATEEVENTTOPICBLOCKNUM,
+		},
+		BlockNumber: 10,
+	}
+	resp := CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, !resp.Success, "cannot check update event topic block number without topic")
+
+	callRestBizParam.Content = "topic"
+	resp = CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, resp.Success, "event topic block number needs no orderId and kms id resp:%+v", resp)
+}
+
+func TestCheckCallRestBizParams_Tapp(t *testing.T) {
+	callRestBizParam := model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId: "accessId",
+			BizId:    "bizid",
+			Token:    "token",
+			Method:   model.GETTAPPINFO,
+		},
+	}
+	resp := CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, !resp.Success, "cannot check get tapp info without requestStr")
+	callRestBizParam.RequestStr = `{"tappId":"tapp"}`
+	resp = CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, resp.Success, "get tapp info needs no orderId and kms id resp:%+v", resp)
+
+	callRestBizParam.Method = model.EXECUTETAPP
+	callRestBizParam.OrderId = "orderId"
+	callRestBizParam.MykmsKeyId = "kmsId"
+	resp = CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, !resp.Success, "cannot check execute tapp without account")
+}
+
+func TestCheckCallRestBizParams_ResourceMap(t *testing.T) {
+	callRestBizParam := model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId: "accessId",
+			BizId:    "bizid",
+			Token:    "token",
+			Method:   model.UPDATERESOURCEMAP,
+		},
+		OrderId: "orderId",
+	}
+	resp := CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, !resp.Success, "cannot check update resource map without requestStr")
+	callRestBizParam.RequestStr = `{"name":"map","entries":{"a":"1"}}`
+	resp = CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, resp.Success, "resource map needs no kms id resp:%+v", resp)
+}
+
+func TestCheckCallRestBizParams_AccessKey(t *testing.T) {
+	callRestBizParam := model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId: "accessId",
+			BizId:    "bizid",
+			Token:    "token",
+			Method:   model.RESETAPPLYKEY,
+		},
+		ApplyAccessKey: "publicKey",
+	}
+	resp := CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, !resp.Success, "cannot check reset apply key without access id")
+	callRestBizParam.RequestStr = "accessId"
+	resp = CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, resp.Success, "reset apply key needs no orderId and kms id resp:%+v", resp)
+
+	callRestBizParam.Method = model.QUERYTENANTKMSLIST
+	resp = CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, !resp.Success, "cannot check query tenant kms list without tenant id")
+	callRestBizParam.TenantId = "tenantId"
+	resp = CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, resp.Success, "query tenant kms list needs no orderId resp:%+v", resp)
+}
+
+func TestCheckCallRestBizParams_Admin(t *testing.T) {
+	callRestBizParam := model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId: "accessId",
+			BizId:    "bizid",
+			Token:    "token",
+			Method:   model.FROZENTENANT,
+		},
+	}
+	resp := CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, !resp.Success, "cannot check frozen tenant without tenant id")
+	callRestBizParam.TenantId = "tenantId"
+	resp = CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, resp.Success, "frozen tenant needs no orderId and kms id resp:%+v", resp)
+
+	callRestBizParam.Method = model.REGISTERBLOCKCHAINCONFIG
+	callRestBizParam.OrderId = "orderId"
+	resp = CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, !resp.Success, "cannot check register blockchain config without config")
+	callRestBizParam.RequestStr = "{}"
+	resp = CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, resp.Success, "register blockchain config needs no kms id resp:%+v", resp)
+
+	callRestBizParam.Method = model.NEWCHAIN
+	resp = CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, resp.Success, "new chain needs no kms id resp:%+v", resp)
+	callRestBizParam.RequestStr = ""
+	resp = CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, !resp.Success, "cannot check new chain without request")
+
+	callRestBizParam.Method = model.DEPOSITWITHADMIN
+	resp = CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, !resp.Success, "cannot check deposit with admin without content")
+	callRestBizParam.Content = "content"
+	resp = CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, resp.Success, "deposit with admin needs no kms id resp:%+v", resp)
+}
+
+func TestCheckCallRestBizParams_SignHashAndParseOutput(t *testing.T) {
+	callRestBizParam := model.CallRestBizParam{
+		BaseParam: model.BaseParam{
+			AccessId:   "accessId",
+			BizId:      "bizid",
+			Token:      "token",
+			Method:     model.SIGNHASH,
+			RequestStr: "hash",
+		},
+	}
+	resp := CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, !resp.Success, "cannot check sign hash without kms id")
+	callRestBizParam.MykmsKeyId = "kmsId"
+	resp = CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, resp.Success, "sign hash needs no orderId resp:%+v", resp)
+
+	callRestBizParam.Method = model.PARSEOUTPUT
+	callRestBizParam.MykmsKeyId = ""
+	resp = CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, !resp.Success, "cannot check parse output without outTypes")
+	callRestBizParam.OutTypes = `["uint256"]`
+	resp = CheckCallRestBizParams(callRestBizParam)
+	require.Truef(t, resp.Success, "parse output needs no orderId and kms id resp:%+v", resp)
+}
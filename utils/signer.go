@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"crypto/rsa"
+)
+
+// Signer signs the shake hand text with the access key.
+type Signer interface {
+	Sign(plain string) (string, error)
+}
+
+type fileSigner struct {
+	priKey string
+}
+
+// NewFileSigner signs with the PEM encoded private key stored at priKey, read on every call.
+func NewFileSigner(priKey string) Signer {
+	return &fileSigner{priKey: priKey}
+}
+
+func (signer *fileSigner) Sign(plain string) (string, error) {
+	return Sign(plain, signer.priKey)
+}
+
+type rsaSigner struct {
+	privateKey *rsa.PrivateKey
+}
+
+// NewRSASigner signs with an in-memory private key.
+func NewRSASigner(privateKey *rsa.PrivateKey) Signer {
+	return &rsaSigner{privateKey: privateKey}
+}
+
+func (signer *rsaSigner) Sign(plain string) (string, error) {
+	return SignWithKey(plain, signer.privateKey)
+}
@@ -0,0 +1,360 @@
+package wasm
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/mychain"
+	"math"
+	"reflect"
+)
+
+// maxDecodeLength bounds the length prefixes accepted by Decode.
+const maxDecodeLength = 1 << 24
+
+// The binary encoding of the mychain WASM runtime: integers are little endian with their natural
+// width, bool is one byte, Identity is its 32 bytes, string is a uint32 length followed by the bytes
+// and a vector is a uint32 element count followed by the elements.
+
+// Encode checks args against types and encodes them one after another.
+func Encode(types []Type, args ...interface{}) ([]byte, error) {
+	values, err := normalizeAll(types, args)
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	for i, t := range types {
+		data = t.encode(data, values[i])
+	}
+	return data, nil
+}
+
+// Decode decodes values of types, the go types are int8..uint64, bool, string, mychain.Identity and
+// slices of them. Trailing bytes are an error.
+func Decode(types []Type, data []byte) ([]interface{}, error) {
+	values := make([]interface{}, len(types))
+	for i, t := range types {
+		value, rest, err := t.decode(data)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+		data = rest
+	}
+	if len(data) != 0 {
+		return nil, fmt.Errorf("wasm: %v trailing bytes after output", len(data))
+	}
+	return values, nil
+}
+
+// EncodeInputParamList validates args against the method signature and encodes them as the
+// inputParamListStr of CallWasmContract: a json array with Identity hex encoded.
+func EncodeInputParamList(signature string, args ...interface{}) (string, error) {
+	parsed, err := ParseSignature(signature)
+	if err != nil {
+		return "", err
+	}
+	values, err := normalizeAll(parsed.Inputs, args)
+	if err != nil {
+		return "", fmt.Errorf("wasm: %v: %w", signature, err)
+	}
+	params := make([]interface{}, len(values))
+	for i, value := range values {
+		params[i] = parsed.Inputs[i].jsonValue(value)
+	}
+	bytes, err := json.Marshal(params)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// DecodeOutput decodes the receipt output of a call made with outTypes.
+func DecodeOutput(outTypes string, output []byte) ([]interface{}, error) {
+	types, err := ParseOutTypes(outTypes)
+	if err != nil {
+		return nil, err
+	}
+	return Decode(types, output)
+}
+
+// Unpack decodes the receipt output of a call made with outTypes into the pointers in dst, which
+// must be one per output. Integers are converted to the pointed type when they fit.
+func Unpack(outTypes string, output []byte, dst ...interface{}) error {
+	values, err := DecodeOutput(outTypes, output)
+	if err != nil {
+		return err
+	}
+	if len(dst) != len(values) {
+		return fmt.Errorf("wasm: need %v destinations,actual:%v", len(values), len(dst))
+	}
+	for i, value := range values {
+		rv := reflect.ValueOf(dst[i])
+		if rv.Kind() != reflect.Ptr || rv.IsNil() {
+			return fmt.Errorf("wasm: destination %v is not a non-nil pointer,actual:%T", i, dst[i])
+		}
+		if err := assign(rv.Elem(), reflect.ValueOf(value)); err != nil {
+			return fmt.Errorf("wasm: output %v: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func normalizeAll(types []Type, args []interface{}) ([]interface{}, error) {
+	if len(args) != len(types) {
+		return nil, fmt.Errorf("wasm: need %v arguments,actual:%v", len(types), len(args))
+	}
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		value, err := types[i].normalize(arg)
+		if err != nil {
+			return nil, fmt.Errorf("wasm: argument %v: %w", i, err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// normalize checks v against t and converts it to the go type of t.
+func (t Type) normalize(v interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(v)
+	for rv.IsValid() && (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("wasm: nil value for %v", t)
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil, fmt.Errorf("wasm: nil value for %v", t)
+	}
+
+	switch t.Kind {
+	case Int8Kind, Int16Kind, Int32Kind, Int64Kind, Uint8Kind, Uint16Kind, Uint32Kind, Uint64Kind:
+		out := reflect.New(t.goType()).Elem()
+		if err := assign(out, rv); err != nil {
+			return nil, err
+		}
+		return out.Interface(), nil
+	case BoolKind:
+		if rv.Kind() != reflect.Bool {
+			return nil, fmt.Errorf("wasm: cannot use %v as %v", rv.Type(), t)
+		}
+		return rv.Bool(), nil
+	case StringKind:
+		if rv.Kind() != reflect.String {
+			return nil, fmt.Errorf("wasm: cannot use %v as %v", rv.Type(), t)
+		}
+		return rv.String(), nil
+	case IdentityKind:
+		if rv.Kind() == reflect.String {
+			return mychain.HexToIdentity(rv.String())
+		}
+		if (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) || rv.Type().Elem().Kind() != reflect.Uint8 ||
+			rv.Len() != mychain.IdentityLength {
+			return nil, fmt.Errorf("wasm: cannot use %v as %v", rv.Type(), t)
+		}
+		identity := mychain.Identity{}
+		reflect.Copy(reflect.ValueOf(identity[:]), rv)
+		return identity, nil
+	case VectorKind:
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, fmt.Errorf("wasm: cannot use %v as %v", rv.Type(), t)
+		}
+		out := reflect.MakeSlice(t.goType(), rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			elem, err := t.Elem.normalize(rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			out.Index(i).Set(reflect.ValueOf(elem))
+		}
+		return out.Interface(), nil
+	}
+	return nil, fmt.Errorf("wasm: unsupported type %v", t)
+}
+
+// encode appends the normalized value v to data.
+func (t Type) encode(data []byte, v interface{}) []byte {
+	switch t.Kind {
+	case Int8Kind:
+		return append(data, byte(v.(int8)))
+	case Int16Kind:
+		return appendUint(data, uint64(v.(int16)), 2)
+	case Int32Kind:
+		return appendUint(data, uint64(v.(int32)), 4)
+	case Int64Kind:
+		return appendUint(data, uint64(v.(int64)), 8)
+	case Uint8Kind:
+		return append(data, v.(uint8))
+	case Uint16Kind:
+		return appendUint(data, uint64(v.(uint16)), 2)
+	case Uint32Kind:
+		return appendUint(data, uint64(v.(uint32)), 4)
+	case Uint64Kind:
+		return appendUint(data, v.(uint64), 8)
+	case BoolKind:
+		if v.(bool) {
+			return append(data, 1)
+		}
+		return append(data, 0)
+	case StringKind:
+		s := v.(string)
+		data = appendUint(data, uint64(len(s)), 4)
+		return append(data, s...)
+	case IdentityKind:
+		identity := v.(mychain.Identity)
+		return append(data, identity[:]...)
+	case VectorKind:
+		rv := reflect.ValueOf(v)
+		data = appendUint(data, uint64(rv.Len()), 4)
+		for i := 0; i < rv.Len(); i++ {
+			data = t.Elem.encode(data, rv.Index(i).Interface())
+		}
+	}
+	return data
+}
+
+func (t Type) decode(data []byte) (interface{}, []byte, error) {
+	if size := t.size(); size > 0 {
+		if len(data) < size {
+			return nil, nil, fmt.Errorf("wasm: output too short to decode %v", t)
+		}
+		word, rest := data[:size], data[size:]
+		switch t.Kind {
+		case Int8Kind:
+			return int8(word[0]), rest, nil
+		case Int16Kind:
+			return int16(binary.LittleEndian.Uint16(word)), rest, nil
+		case Int32Kind:
+			return int32(binary.LittleEndian.Uint32(word)), rest, nil
+		case Int64Kind:
+			return int64(binary.LittleEndian.Uint64(word)), rest, nil
+		case Uint8Kind:
+			return word[0], rest, nil
+		case Uint16Kind:
+			return binary.LittleEndian.Uint16(word), rest, nil
+		case Uint32Kind:
+			return binary.LittleEndian.Uint32(word), rest, nil
+		case Uint64Kind:
+			return binary.LittleEndian.Uint64(word), rest, nil
+		case BoolKind:
+			if word[0] > 1 {
+				return nil, nil, fmt.Errorf("wasm: invalid bool %v", word[0])
+			}
+			return word[0] == 1, rest, nil
+		case IdentityKind:
+			identity := mychain.Identity{}
+			copy(identity[:], word)
+			return identity, rest, nil
+		}
+	}
+
+	if t.Kind != StringKind && t.Kind != VectorKind {
+		return nil, nil, fmt.Errorf("wasm: unsupported type %v", t)
+	}
+	if len(data) < 4 {
+		return nil, nil, fmt.Errorf("wasm: output too short to decode %v", t)
+	}
+	n := int(binary.LittleEndian.Uint32(data))
+	data = data[4:]
+	if n > maxDecodeLength {
+		return nil, nil, fmt.Errorf("wasm: length %v of %v too large", n, t)
+	}
+	if t.Kind == StringKind {
+		if n > len(data) {
+			return nil, nil, fmt.Errorf("wasm: output too short to decode %v of length %v", t, n)
+		}
+		return string(data[:n]), data[n:], nil
+	}
+	// checked before allocating so that a forged length cannot exhaust memory
+	if n*t.Elem.minSize() > len(data) {
+		return nil, nil, fmt.Errorf("wasm: output too short to decode %v of length %v", t, n)
+	}
+	out := reflect.MakeSlice(t.goType(), n, n)
+	for i := 0; i < n; i++ {
+		elem, rest, err := t.Elem.decode(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		out.Index(i).Set(reflect.ValueOf(elem))
+		data = rest
+	}
+	return out.Interface(), data, nil
+}
+
+// jsonValue converts a normalized value to its form in inputParamListStr.
+func (t Type) jsonValue(v interface{}) interface{} {
+	switch t.Kind {
+	case IdentityKind:
+		return v.(mychain.Identity).Hex()
+	case VectorKind:
+		rv := reflect.ValueOf(v)
+		values := make([]interface{}, rv.Len())
+		for i := range values {
+			values[i] = t.Elem.jsonValue(rv.Index(i).Interface())
+		}
+		return values
+	}
+	return v
+}
+
+func appendUint(data []byte, n uint64, size int) []byte {
+	for i := 0; i < size; i++ {
+		data = append(data, byte(n>>(8*uint(i))))
+	}
+	return data
+}
+
+// assign stores src into dst, integers are converted between kinds when they fit.
+func assign(dst, src reflect.Value) error {
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+	switch src.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := src.Int()
+		switch dst.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if dst.OverflowInt(n) {
+				return fmt.Errorf("wasm: %v overflows %v", n, dst.Type())
+			}
+			dst.SetInt(n)
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if n < 0 || dst.OverflowUint(uint64(n)) {
+				return fmt.Errorf("wasm: %v overflows %v", n, dst.Type())
+			}
+			dst.SetUint(uint64(n))
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n := src.Uint()
+		switch dst.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if n > math.MaxInt64 || dst.OverflowInt(int64(n)) {
+				return fmt.Errorf("wasm: %v overflows %v", n, dst.Type())
+			}
+			dst.SetInt(int64(n))
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if dst.OverflowUint(n) {
+				return fmt.Errorf("wasm: %v overflows %v", n, dst.Type())
+			}
+			dst.SetUint(n)
+			return nil
+		}
+	case reflect.Slice:
+		if dst.Kind() == reflect.Slice {
+			out := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+			for i := 0; i < src.Len(); i++ {
+				if err := assign(out.Index(i), src.Index(i)); err != nil {
+					return err
+				}
+			}
+			dst.Set(out)
+			return nil
+		}
+	}
+	return fmt.Errorf("wasm: cannot assign %v to %v", src.Type(), dst.Type())
+}
@@ -0,0 +1,163 @@
+package wasm
+
+import (
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/mychain"
+	"github.com/stretchr/testify/require"
+	"math"
+	"runtime"
+	"testing"
+)
+
+func TestEncodeDecode_WasmParaTypes(t *testing.T) {
+	identity := mychain.NewIdentity("rest_biz_test_account")
+	cases := []struct {
+		paraType model.WasmParaType
+		in       interface{}
+		out      interface{}
+	}{
+		{model.INT8, -8, int8(-8)},
+		{model.INT16, int16(math.MinInt16), int16(math.MinInt16)},
+		{model.INT32, 1 << 20, int32(1 << 20)},
+		{model.INT64, int64(math.MinInt64), int64(math.MinInt64)},
+		{model.UINT8, 255, uint8(255)},
+		{model.UINT16, uint(65535), uint16(65535)},
+		{model.UINT32, uint32(math.MaxUint32), uint32(math.MaxUint32)},
+		{model.UINT64, uint64(math.MaxUint64), uint64(math.MaxUint64)},
+		{model.VECTORINT8, []int{-1, 1}, []int8{-1, 1}},
+		{model.VECTORINT16, []int16{2}, []int16{2}},
+		{model.VECTORINT32, []int32{}, []int32{}},
+		{model.VECTORINT64, []int64{-3, 3}, []int64{-3, 3}},
+		{model.VECTORUINT8, []byte("abc"), []uint8("abc")},
+		{model.VECTORUINT16, []int{1, 2}, []uint16{1, 2}},
+		{model.VECTORUINT32, []uint32{4}, []uint32{4}},
+		{model.VECTORUINT64, []uint64{5}, []uint64{5}},
+		{model.STRING, "我是中国人", "我是中国人"},
+		{model.VECTORSTRING, []string{"a", ""}, []string{"a", ""}},
+		{model.IDENTITY, identity, identity},
+		{model.IDENTITY, identity.Hex(), identity},
+		{model.VECTORIDENTITY, []string{identity.Hex()}, []mychain.Identity{identity}},
+		{model.BOOL, true, true},
+		{model.VECTORBOOL, []bool{true, false}, []bool{true, false}},
+	}
+	for _, c := range cases {
+		typ, err := NewType(string(c.paraType))
+		require.NoErrorf(t, err, "type:%v", c.paraType)
+		require.Equal(t, string(c.paraType), typ.String())
+		stringType := Type{Kind: StringKind}
+		data, err := Encode([]Type{typ, stringType}, c.in, "tail")
+		require.NoErrorf(t, err, "type:%v", c.paraType)
+		values, err := Decode([]Type{typ, stringType}, data)
+		require.NoErrorf(t, err, "type:%v", c.paraType)
+		require.Equalf(t, c.out, values[0], "type:%v", c.paraType)
+		require.Equal(t, "tail", values[1])
+	}
+}
+
+func TestEncode_LittleEndian(t *testing.T) {
+	types, err := ParseOutTypes(`["int32","string","uint16[]","bool"]`)
+	require.NoError(t, err)
+	data, err := Encode(types, -2, "hi", []uint16{0x0102}, true)
+	require.NoError(t, err)
+	require.Equal(t, []byte{
+		0xfe, 0xff, 0xff, 0xff,
+		2, 0, 0, 0, 'h', 'i',
+		1, 0, 0, 0, 0x02, 0x01,
+		1,
+	}, data)
+}
+
+func TestEncode_Invalid(t *testing.T) {
+	cases := map[string]interface{}{
+		"int8":       128,
+		"uint8":      -1,
+		"uint64":     int64(-1),
+		"int64":      uint64(math.MaxUint64),
+		"bool":       1,
+		"string":     nil,
+		"Identity":   "00",
+		"Identity[]": []string{"zz"},
+		"int32[]":    "1,2",
+	}
+	for paraType, in := range cases {
+		typ, err := NewType(paraType)
+		require.NoError(t, err)
+		_, err = Encode([]Type{typ}, in)
+		require.Errorf(t, err, "type:%v in:%v", paraType, in)
+	}
+	_, err := NewType("int32[][]")
+	require.Error(t, err)
+	_, err = NewType("float")
+	require.Error(t, err)
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	types, err := ParseOutTypes(`["string"]`)
+	require.NoError(t, err)
+	_, err = Decode(types, []byte{5, 0, 0, 0, 'a'})
+	require.Error(t, err)
+	_, err = Decode(types, []byte{1, 0, 0, 0, 'a', 'b'})
+	require.Error(t, err)
+	_, err = Decode([]Type{{Kind: BoolKind}}, []byte{2})
+	require.Error(t, err)
+	_, err = Decode([]Type{{Kind: VectorKind, Elem: &Type{Kind: Int64Kind}}}, []byte{0xff, 0xff, 0xff, 0xff})
+	require.Error(t, err)
+}
+
+func TestDecode_ForgedLength(t *testing.T) {
+	vector := func(elem Type) Type { return Type{Kind: VectorKind, Elem: &elem} }
+	for _, typ := range []Type{vector(Type{Kind: IdentityKind}), vector(Type{Kind: StringKind}), vector(vector(Type{Kind: Uint8Kind}))} {
+		// a length of 0xffffff followed by a single element
+		data := []byte{0xff, 0xff, 0xff, 0, 1, 0, 0, 0, 'a'}
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		_, err := Decode([]Type{typ}, data)
+		runtime.ReadMemStats(&after)
+		require.Errorf(t, err, "type:%v", typ)
+		require.Contains(t, err.Error(), "too short")
+		require.Lessf(t, after.TotalAlloc-before.TotalAlloc, uint64(1<<20), "type:%v allocated before failing", typ)
+	}
+}
+
+func TestEncodeInputParamList(t *testing.T) {
+	identity := mychain.NewIdentity("to")
+	inputParamListStr, err := EncodeInputParamList("transfer(Identity, uint64, string[], bool)", identity, 10, []string{"memo"}, false)
+	require.NoError(t, err)
+	require.Equal(t, `["`+identity.Hex()+`",10,["memo"],false]`, inputParamListStr)
+
+	inputParamListStr, err = EncodeInputParamList("get()")
+	require.NoError(t, err)
+	require.Equal(t, "[]", inputParamListStr)
+
+	_, err = EncodeInputParamList("add(int32,int32)", 1)
+	require.Error(t, err)
+	_, err = EncodeInputParamList("add(int32,int32)", 1, "2")
+	require.Error(t, err)
+	_, err = EncodeInputParamList("add(int32")
+	require.Error(t, err)
+	_, err = EncodeInputParamList("add(void)")
+	require.Error(t, err)
+}
+
+func TestUnpack(t *testing.T) {
+	types, err := ParseOutTypes(`["int32","uint8[]","void"]`)
+	require.NoError(t, err)
+	require.Equal(t, `["int32","uint8[]"]`, OutTypes(types...))
+	require.Equal(t, `["void"]`, OutTypes())
+	output, err := Encode(types, 7, []byte{1, 2})
+	require.NoError(t, err)
+
+	var sum int
+	var list []uint32
+	require.NoError(t, Unpack(`["int32","uint8[]"]`, output, &sum, &list))
+	require.Equal(t, 7, sum)
+	require.Equal(t, []uint32{1, 2}, list)
+
+	var small int8
+	output, err = Encode(types, 1000, []byte{})
+	require.NoError(t, err)
+	require.Error(t, Unpack(`["int32","uint8[]"]`, output, &small, &list))
+	require.Error(t, Unpack(`["int32","uint8[]"]`, output, &sum))
+	require.Error(t, Unpack(`["int32","uint8[]"]`, output, sum, &list))
+	require.NoError(t, Unpack(`["void"]`, nil))
+}
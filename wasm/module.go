@@ -0,0 +1,232 @@
+package wasm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"github.com/go-interpreter/wagon/validate"
+	wagon "github.com/go-interpreter/wagon/wasm"
+	"strings"
+)
+
+// Function is a function exported or imported by a contract module, Params and Results are the wasm
+// value types i32, i64, f32 and f64.
+type Function struct {
+	Name    string
+	Params  []string
+	Results []string
+}
+
+func (function Function) String() string {
+	return fmt.Sprintf("%v(%v) (%v)", function.Name, strings.Join(function.Params, ","), strings.Join(function.Results, ","))
+}
+
+// Module is a parsed and verified contract module.
+type Module struct {
+	Exports []Function
+	// Imports are named module.field
+	Imports []Function
+	module  *wagon.Module
+}
+
+// ParseModule decodes code and verifies every function body with wagon. Imports, the host abi of the
+// chain, are resolved to stubs: functions return zero, globals are zero and memories are empty.
+func ParseModule(code []byte) (parsed *Module, err error) {
+	defer func() {
+		// wagon panics on some malformed inputs
+		if r := recover(); r != nil {
+			parsed, err = nil, fmt.Errorf("wasm: invalid module,err:%v", r)
+		}
+	}()
+	decoded, err := wagon.DecodeModule(bytes.NewReader(code))
+	if err != nil {
+		return nil, fmt.Errorf("wasm: invalid module,err:%w", err)
+	}
+	module, err := wagon.ReadModule(bytes.NewReader(code), resolveStubs(decoded))
+	if err != nil {
+		return nil, fmt.Errorf("wasm: invalid module,err:%w", err)
+	}
+	if err := validate.VerifyModule(module); err != nil {
+		return nil, fmt.Errorf("wasm: invalid module,err:%w", err)
+	}
+
+	parsed = &Module{module: module}
+	if module.Import != nil {
+		for _, entry := range module.Import.Entries {
+			if funcImport, ok := entry.Type.(wagon.FuncImport); ok {
+				sig := module.Types.Entries[funcImport.Type]
+				parsed.Imports = append(parsed.Imports, newFunction(entry.ModuleName+"."+entry.FieldName, sig))
+			}
+		}
+	}
+	if module.Export != nil {
+		for _, name := range module.Export.Names {
+			entry := module.Export.Entries[name]
+			if entry.Kind != wagon.ExternalFunction {
+				continue
+			}
+			fn := module.GetFunction(int(entry.Index))
+			if fn == nil {
+				return nil, fmt.Errorf("wasm: export %v has invalid function index %v", name, entry.Index)
+			}
+			parsed.Exports = append(parsed.Exports, newFunction(name, *fn.Sig))
+		}
+	}
+	return parsed, nil
+}
+
+// ParseContractCode parses the hex encoded contract code passed to DeployWasmContract.
+func ParseContractCode(contractCode string) (*Module, error) {
+	contractCode = strings.TrimPrefix(strings.TrimPrefix(contractCode, "0x"), "0X")
+	code, err := hex.DecodeString(contractCode)
+	if err != nil {
+		return nil, fmt.Errorf("wasm: contract code is not hex encoded,err:%w", err)
+	}
+	return ParseModule(code)
+}
+
+// Export returns the exported function called name.
+func (module *Module) Export(name string) (Function, bool) {
+	for _, function := range module.Exports {
+		if function.Name == name {
+			return function, true
+		}
+	}
+	return Function{}, false
+}
+
+// CheckMethod reports whether the method signature can be called on the module. An export without
+// params and results reads its arguments through the host abi and accepts any signature, otherwise
+// every param must have the wasm value type of its WasmParaType: i64 for int64 and uint64, i32 for
+// the other integers and bool, and an i32 pointer for string, Identity and vectors.
+func (module *Module) CheckMethod(signature string) error {
+	parsed, err := ParseSignature(signature)
+	if err != nil {
+		return err
+	}
+	function, ok := module.Export(parsed.Name)
+	if !ok {
+		return fmt.Errorf("wasm: method %v is not exported", parsed.Name)
+	}
+	if len(function.Params) == 0 && len(function.Results) == 0 {
+		return nil
+	}
+	if len(function.Params) != len(parsed.Inputs) {
+		return fmt.Errorf("wasm: method %v has %v params,export:%v", signature, len(parsed.Inputs), function)
+	}
+	for i, input := range parsed.Inputs {
+		if valueType := input.valueType(); valueType != function.Params[i] {
+			return fmt.Errorf("wasm: param %v of %v is %v and needs %v,export:%v", i, signature, input, valueType, function)
+		}
+	}
+	return nil
+}
+
+// CheckUpgrade returns an error listing the exports of registered that upgraded removes or whose params
+// or results it changes. New exports are compatible.
+func CheckUpgrade(registered, upgraded *Module) error {
+	var changes []string
+	for _, function := range registered.Exports {
+		next, ok := upgraded.Export(function.Name)
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("export %v is removed", function.Name))
+		case !sameValueTypes(function.Params, next.Params) || !sameValueTypes(function.Results, next.Results):
+			changes = append(changes, fmt.Sprintf("export %v changed to %v", function, next))
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return fmt.Errorf("wasm: upgrade is not compatible,%v", strings.Join(changes, ";"))
+}
+
+func sameValueTypes(types, others []string) bool {
+	if len(types) != len(others) {
+		return false
+	}
+	for i := range types {
+		if types[i] != others[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// valueType is the wasm value type t is passed as.
+func (t Type) valueType() string {
+	if t.Kind == Int64Kind || t.Kind == Uint64Kind {
+		return wagon.ValueTypeI64.String()
+	}
+	return wagon.ValueTypeI32.String()
+}
+
+func newFunction(name string, sig wagon.FunctionSig) Function {
+	function := Function{Name: name}
+	for _, param := range sig.ParamTypes {
+		function.Params = append(function.Params, param.String())
+	}
+	for _, result := range sig.ReturnTypes {
+		function.Results = append(function.Results, result.String())
+	}
+	return function
+}
+
+// resolveStubs resolves every imported module to a module exporting a stub for each entry the
+// decoded module imports from it.
+func resolveStubs(decoded *wagon.Module) wagon.ResolveFunc {
+	return func(name string) (*wagon.Module, error) {
+		stub := wagon.NewModule()
+		stub.Export.Entries = make(map[string]wagon.ExportEntry)
+		for _, entry := range decoded.Import.Entries {
+			if entry.ModuleName != name {
+				continue
+			}
+			export := wagon.ExportEntry{FieldStr: entry.FieldName, Kind: entry.Type.Kind()}
+			switch importType := entry.Type.(type) {
+			case wagon.FuncImport:
+				if decoded.Types == nil || int(importType.Type) >= len(decoded.Types.Entries) {
+					return nil, wagon.InvalidFunctionIndexError(importType.Type)
+				}
+				sig := decoded.Types.Entries[importType.Type]
+				export.Index = uint32(len(stub.FunctionIndexSpace))
+				stub.FunctionIndexSpace = append(stub.FunctionIndexSpace, wagon.Function{
+					Sig:  &sig,
+					Body: &wagon.FunctionBody{Code: stubCode(sig)},
+					Name: entry.FieldName,
+				})
+			case wagon.GlobalVarImport:
+				export.Index = uint32(len(stub.GlobalIndexSpace))
+				stub.GlobalIndexSpace = append(stub.GlobalIndexSpace, wagon.GlobalEntry{
+					Type: importType.Type,
+					// init expressions keep their end opcode
+					Init: append(stubCode(wagon.FunctionSig{ReturnTypes: []wagon.ValueType{importType.Type.Type}}), 0x0b),
+				})
+			case wagon.MemoryImport:
+				stub.LinearMemoryIndexSpace = [][]byte{{}}
+			default:
+				return nil, fmt.Errorf("wasm: importing %v %v.%v is not supported", entry.Type.Kind(), name, entry.FieldName)
+			}
+			stub.Export.Entries[entry.FieldName] = export
+		}
+		return stub, nil
+	}
+}
+
+// stubCode returns zero for each result of sig, wagon drops the end opcode of function bodies.
+func stubCode(sig wagon.FunctionSig) []byte {
+	var code []byte
+	for _, result := range sig.ReturnTypes {
+		switch result {
+		case wagon.ValueTypeI32:
+			code = append(code, 0x41, 0)
+		case wagon.ValueTypeI64:
+			code = append(code, 0x42, 0)
+		case wagon.ValueTypeF32:
+			code = append(code, 0x43, 0, 0, 0, 0)
+		case wagon.ValueTypeF64:
+			code = append(code, 0x44, 0, 0, 0, 0, 0, 0, 0, 0)
+		}
+	}
+	return code
+}
@@ -0,0 +1,141 @@
+package wasm
+
+import (
+	"bytes"
+	"encoding/hex"
+	wagon "github.com/go-interpreter/wagon/wasm"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var (
+	i32 = wagon.ValueTypeI32
+	i64 = wagon.ValueTypeI64
+)
+
+type testFunc struct {
+	name    string
+	params  []wagon.ValueType
+	results []wagon.ValueType
+	code    []byte
+}
+
+// buildModule encodes a module with one page of memory, importing imports from env and exporting funcs.
+func buildModule(t *testing.T, imports []testFunc, funcs []testFunc) []byte {
+	types := &wagon.SectionTypes{}
+	importSection := &wagon.SectionImports{}
+	functions := &wagon.SectionFunctions{}
+	exports := &wagon.SectionExports{Entries: make(map[string]wagon.ExportEntry)}
+	code := &wagon.SectionCode{}
+	memory := &wagon.SectionMemories{Entries: []wagon.Memory{{Limits: wagon.ResizableLimits{Initial: 1}}}}
+	for _, fn := range imports {
+		importSection.Entries = append(importSection.Entries, wagon.ImportEntry{
+			ModuleName: "env",
+			FieldName:  fn.name,
+			Type:       wagon.FuncImport{Type: uint32(len(types.Entries))},
+		})
+		types.Entries = append(types.Entries, wagon.FunctionSig{Form: 0x60, ParamTypes: fn.params, ReturnTypes: fn.results})
+	}
+	for i, fn := range funcs {
+		functions.Types = append(functions.Types, uint32(len(types.Entries)))
+		types.Entries = append(types.Entries, wagon.FunctionSig{Form: 0x60, ParamTypes: fn.params, ReturnTypes: fn.results})
+		exports.Entries[fn.name] = wagon.ExportEntry{FieldStr: fn.name, Kind: wagon.ExternalFunction, Index: uint32(len(imports) + i)}
+		code.Bodies = append(code.Bodies, wagon.FunctionBody{Code: fn.code})
+	}
+	module := &wagon.Module{Sections: []wagon.Section{types}}
+	if len(imports) > 0 {
+		module.Sections = append(module.Sections, importSection)
+	}
+	module.Sections = append(module.Sections, functions, memory, exports, code)
+	buf := &bytes.Buffer{}
+	require.NoError(t, wagon.EncodeModule(buf, module))
+	return buf.Bytes()
+}
+
+var (
+	// get_local 0, get_local 1, i32.add, the end opcode is added by EncodeModule
+	addCode = []byte{0x20, 0, 0x20, 1, 0x6a}
+	// call 0, drop
+	callHostCode = []byte{0x10, 0, 0x1a}
+)
+
+func testModule(t *testing.T) []byte {
+	return buildModule(t,
+		[]testFunc{{name: "GetCallArgs", results: []wagon.ValueType{i32}}},
+		[]testFunc{
+			{name: "add", params: []wagon.ValueType{i32, i32}, results: []wagon.ValueType{i32}, code: addCode},
+			{name: "transfer", code: callHostCode},
+			{name: "balance", params: []wagon.ValueType{i32, i64}, results: []wagon.ValueType{i64}, code: []byte{0x20, 1}},
+		})
+}
+
+func TestParseModule(t *testing.T) {
+	module, err := ParseModule(testModule(t))
+	require.NoError(t, err)
+	require.Equal(t, []Function{{Name: "env.GetCallArgs", Results: []string{"i32"}}}, module.Imports)
+	require.Len(t, module.Exports, 3)
+	add, ok := module.Export("add")
+	require.True(t, ok)
+	require.Equal(t, Function{Name: "add", Params: []string{"i32", "i32"}, Results: []string{"i32"}}, add)
+
+	module, err = ParseContractCode("0x" + hex.EncodeToString(testModule(t)))
+	require.NoError(t, err)
+	require.Len(t, module.Exports, 3)
+}
+
+func TestParseModule_Invalid(t *testing.T) {
+	code := testModule(t)
+	cases := map[string][]byte{
+		"empty":     {},
+		"magic":     []byte("not wasm"),
+		"truncated": code[:len(code)-3],
+		// i32.add with a single operand
+		"stack": buildModule(t, nil, []testFunc{{name: "f", params: []wagon.ValueType{i32}, results: []wagon.ValueType{i32}, code: []byte{0x20, 0, 0x6a}}}),
+		// result type does not match
+		"result": buildModule(t, nil, []testFunc{{name: "f", params: []wagon.ValueType{i32}, results: []wagon.ValueType{i64}, code: []byte{0x20, 0}}}),
+	}
+	for name, code := range cases {
+		_, err := ParseModule(code)
+		require.Errorf(t, err, "case:%v", name)
+	}
+	_, err := ParseContractCode("zz")
+	require.Error(t, err)
+}
+
+func TestModule_CheckMethod(t *testing.T) {
+	module, err := ParseModule(testModule(t))
+	require.NoError(t, err)
+	require.NoError(t, module.CheckMethod("add(int32,uint16)"))
+	require.NoError(t, module.CheckMethod("add(bool,string)"))
+	require.NoError(t, module.CheckMethod("transfer(Identity,uint64)"))
+	require.NoError(t, module.CheckMethod("balance(Identity[],int64)"))
+
+	require.Error(t, module.CheckMethod("missing()"))
+	require.Error(t, module.CheckMethod("add(int32)"))
+	require.Error(t, module.CheckMethod("add(int32,int64)"))
+	require.Error(t, module.CheckMethod("balance(int32,int32)"))
+	require.Error(t, module.CheckMethod("add(int32,float)"))
+}
+
+func TestCheckUpgrade(t *testing.T) {
+	registered, err := ParseModule(testModule(t))
+	require.NoError(t, err)
+	require.NoError(t, CheckUpgrade(registered, registered))
+
+	funcs := []testFunc{
+		{name: "add", params: []wagon.ValueType{i32, i32}, results: []wagon.ValueType{i32}, code: addCode},
+		{name: "transfer", code: callHostCode},
+		{name: "balance", params: []wagon.ValueType{i32, i64}, results: []wagon.ValueType{i64}, code: []byte{0x20, 1}},
+		{name: "sub", params: []wagon.ValueType{i32, i32}, results: []wagon.ValueType{i32}, code: []byte{0x20, 0, 0x20, 1, 0x6b}},
+	}
+	imports := []testFunc{{name: "GetCallArgs", results: []wagon.ValueType{i32}}}
+	upgraded, err := ParseModule(buildModule(t, imports, funcs))
+	require.NoError(t, err)
+	require.NoError(t, CheckUpgrade(registered, upgraded), "adding exports is compatible")
+	require.Error(t, CheckUpgrade(upgraded, registered))
+
+	funcs[2] = testFunc{name: "balance", params: []wagon.ValueType{i32, i32}, results: []wagon.ValueType{i32}, code: []byte{0x20, 1}}
+	changed, err := ParseModule(buildModule(t, imports, funcs))
+	require.NoError(t, err)
+	require.Error(t, CheckUpgrade(registered, changed))
+}
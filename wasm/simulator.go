@@ -0,0 +1,360 @@
+package wasm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/mychain"
+	"github.com/go-interpreter/wagon/exec"
+	wagon "github.com/go-interpreter/wagon/wasm"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// HostModule is the module name the simulator host functions are imported from.
+const HostModule = "env"
+
+// ErrReverted is returned when a contract calls Revert, the message is appended to the error.
+var ErrReverted = errors.New("contract reverted")
+
+// Simulator runs WASM contracts offline with an in-memory storage per contract. Accounts and
+// contracts are identified by mychain.NewIdentity of their name. Calls are serialized and a call that
+// fails leaves the storage untouched. There is no gas metering.
+//
+// Contracts reach the chain through these functions imported from HostModule, pointers and lengths
+// are i32 offsets into the contract memory:
+//
+//	GetCallArgsSize() i32                 size of the encoded call args
+//	GetCallArgs(ptr)                      copies the call args, encoded with Encode, to ptr
+//	SetReturnValue(ptr, len)              sets the output, encoded as Encode does for the outTypes
+//	GetSender(ptr)                        copies the 32 byte identity of the caller to ptr
+//	GetSelf(ptr)                          copies the 32 byte identity of the contract to ptr
+//	GetBlockNumber() i64
+//	GetBlockTimestamp() i64               milliseconds
+//	GetStorageSize(keyPtr, keyLen) i32    size of the value, -1 if the key is not set
+//	GetStorage(keyPtr, keyLen, valuePtr) i32
+//	                                      copies the value to valuePtr and returns its size, -1 if the key is not set
+//	SetStorage(keyPtr, keyLen, valuePtr, valueLen)
+//	DeleteStorage(keyPtr, keyLen)
+//	Log(ptr, len)                         appends a message to the logs of the call
+//	Revert(ptr, len)                      aborts the call with a message
+//
+// Exports with params receive the args directly instead, which only works for integer and bool
+// params, and an export returning a value with a single scalar outType has it as output.
+type Simulator struct {
+	mu          sync.Mutex
+	contracts   map[mychain.Identity]*wagon.Module
+	storage     map[mychain.Identity]map[string][]byte
+	blockNumber int64
+	host        *wagon.Module
+	current     *invocation
+}
+
+// CallResult is the outcome of a successful call.
+type CallResult struct {
+	Output      []byte
+	Values      []interface{}
+	Logs        []string
+	BlockNumber int64
+}
+
+// invocation is the state of the running call, read by the host functions.
+type invocation struct {
+	sender    mychain.Identity
+	self      mychain.Identity
+	args      []byte
+	output    []byte
+	hasOutput bool
+	storage   map[string][]byte
+	logs      []string
+	reverted  *string
+	timestamp int64
+}
+
+func NewSimulator() *Simulator {
+	sim := &Simulator{
+		contracts: make(map[mychain.Identity]*wagon.Module),
+		storage:   make(map[mychain.Identity]map[string][]byte),
+	}
+	sim.host = sim.hostModule()
+	return sim
+}
+
+// Deploy verifies code like ParseModule and registers it as contractName, replacing any previous
+// code but keeping its storage.
+func (sim *Simulator) Deploy(contractName string, code []byte) error {
+	if _, err := ParseModule(code); err != nil {
+		return err
+	}
+	module, err := wagon.ReadModule(bytes.NewReader(code), func(name string) (*wagon.Module, error) {
+		if name != HostModule {
+			return nil, fmt.Errorf("wasm: unknown import module %v", name)
+		}
+		return sim.host, nil
+	})
+	if err != nil {
+		return fmt.Errorf("wasm: failed to link module,err:%w", err)
+	}
+
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	identity := mychain.NewIdentity(contractName)
+	sim.contracts[identity] = module
+	if sim.storage[identity] == nil {
+		sim.storage[identity] = make(map[string][]byte)
+	}
+	sim.blockNumber++
+	return nil
+}
+
+// Call runs methodSignature of contractName on behalf of sender and decodes the output with outTypes.
+func (sim *Simulator) Call(sender, contractName, methodSignature, outTypes string, args ...interface{}) (CallResult, error) {
+	signature, err := ParseSignature(methodSignature)
+	if err != nil {
+		return CallResult{}, err
+	}
+	outputTypes, err := ParseOutTypes(outTypes)
+	if err != nil {
+		return CallResult{}, err
+	}
+	values, err := normalizeAll(signature.Inputs, args)
+	if err != nil {
+		return CallResult{}, fmt.Errorf("wasm: %v: %w", methodSignature, err)
+	}
+	var encodedArgs []byte
+	for i, t := range signature.Inputs {
+		encodedArgs = t.encode(encodedArgs, values[i])
+	}
+
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	self := mychain.NewIdentity(contractName)
+	module, ok := sim.contracts[self]
+	if !ok {
+		return CallResult{}, fmt.Errorf("wasm: contract %v is not deployed", contractName)
+	}
+	entry, ok := module.Export.Entries[signature.Name]
+	if !ok || entry.Kind != wagon.ExternalFunction {
+		return CallResult{}, fmt.Errorf("wasm: method %v is not exported", signature.Name)
+	}
+	fn := module.GetFunction(int(entry.Index))
+	params, err := directParams(fn.Sig, signature, values)
+	if err != nil {
+		return CallResult{}, err
+	}
+
+	inv := &invocation{
+		sender:    mychain.NewIdentity(sender),
+		self:      self,
+		args:      encodedArgs,
+		storage:   make(map[string][]byte, len(sim.storage[self])),
+		timestamp: time.Now().UnixNano() / 1e6,
+	}
+	for key, value := range sim.storage[self] {
+		inv.storage[key] = value
+	}
+	sim.current = inv
+	defer func() { sim.current = nil }()
+
+	vm, err := exec.NewVM(module)
+	if err != nil {
+		return CallResult{}, fmt.Errorf("wasm: failed to instantiate %v,err:%w", contractName, err)
+	}
+	vm.RecoverPanic = true
+	ret, err := vm.ExecCode(int64(entry.Index), params...)
+	if inv.reverted != nil {
+		return CallResult{}, fmt.Errorf("%w,message:%v", ErrReverted, *inv.reverted)
+	}
+	if err != nil {
+		return CallResult{}, fmt.Errorf("wasm: %v trapped,err:%w", methodSignature, err)
+	}
+
+	output := inv.output
+	if !inv.hasOutput && len(outputTypes) == 1 {
+		// the low bytes of the result are the little endian encoding of integers and bool
+		switch raw := ret.(type) {
+		case uint32:
+			if size := outputTypes[0].size(); size > 0 && size <= 4 {
+				output = appendUint(nil, uint64(raw), size)
+			}
+		case uint64:
+			if size := outputTypes[0].size(); size > 0 && size <= 8 {
+				output = appendUint(nil, raw, size)
+			}
+		}
+	}
+	decoded, err := Decode(outputTypes, output)
+	if err != nil {
+		return CallResult{}, err
+	}
+	sim.storage[self] = inv.storage
+	sim.blockNumber++
+	return CallResult{Output: output, Values: decoded, Logs: inv.logs, BlockNumber: sim.blockNumber}, nil
+}
+
+// Storage returns the value of key in the storage of contractName.
+func (sim *Simulator) Storage(contractName, key string) ([]byte, bool) {
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	value, ok := sim.storage[mychain.NewIdentity(contractName)][key]
+	return value, ok
+}
+
+// SetStorage sets key in the storage of contractName, to prepare the state of a test.
+func (sim *Simulator) SetStorage(contractName, key string, value []byte) {
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	identity := mychain.NewIdentity(contractName)
+	if sim.storage[identity] == nil {
+		sim.storage[identity] = make(map[string][]byte)
+	}
+	sim.storage[identity][key] = value
+}
+
+func (sim *Simulator) BlockNumber() int64 {
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	return sim.blockNumber
+}
+
+// directParams converts the args to the params of an export that takes them directly.
+func directParams(sig *wagon.FunctionSig, signature Signature, values []interface{}) ([]uint64, error) {
+	if len(sig.ParamTypes) == 0 {
+		return nil, nil
+	}
+	if len(sig.ParamTypes) != len(values) {
+		return nil, fmt.Errorf("wasm: method %v has %v params,export:%v", signature, len(values), sig)
+	}
+	params := make([]uint64, len(values))
+	for i, value := range values {
+		rv := reflect.ValueOf(value)
+		switch rv.Kind() {
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			params[i] = uint64(rv.Int())
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			params[i] = rv.Uint()
+		case reflect.Bool:
+			if rv.Bool() {
+				params[i] = 1
+			}
+		default:
+			return nil, fmt.Errorf("wasm: param %v of %v cannot be passed directly,use the host abi", i, signature)
+		}
+		if sig.ParamTypes[i] == wagon.ValueTypeI32 {
+			params[i] = uint64(uint32(params[i]))
+		}
+	}
+	return params, nil
+}
+
+// hostModule builds the HostModule exports, bound to the running invocation.
+func (sim *Simulator) hostModule() *wagon.Module {
+	functions := []struct {
+		name string
+		fn   interface{}
+	}{
+		{"GetCallArgsSize", func(proc *exec.Process) int32 {
+			return int32(len(sim.current.args))
+		}},
+		{"GetCallArgs", func(proc *exec.Process, ptr uint32) {
+			write(proc, ptr, sim.current.args)
+		}},
+		{"SetReturnValue", func(proc *exec.Process, ptr, length uint32) {
+			sim.current.output = read(proc, ptr, length)
+			sim.current.hasOutput = true
+		}},
+		{"GetSender", func(proc *exec.Process, ptr uint32) {
+			write(proc, ptr, sim.current.sender[:])
+		}},
+		{"GetSelf", func(proc *exec.Process, ptr uint32) {
+			write(proc, ptr, sim.current.self[:])
+		}},
+		{"GetBlockNumber", func(proc *exec.Process) int64 {
+			return sim.blockNumber + 1
+		}},
+		{"GetBlockTimestamp", func(proc *exec.Process) int64 {
+			return sim.current.timestamp
+		}},
+		{"GetStorageSize", func(proc *exec.Process, keyPtr, keyLength uint32) int32 {
+			value, ok := sim.current.storage[string(read(proc, keyPtr, keyLength))]
+			if !ok {
+				return -1
+			}
+			return int32(len(value))
+		}},
+		{"GetStorage", func(proc *exec.Process, keyPtr, keyLength, valuePtr uint32) int32 {
+			value, ok := sim.current.storage[string(read(proc, keyPtr, keyLength))]
+			if !ok {
+				return -1
+			}
+			write(proc, valuePtr, value)
+			return int32(len(value))
+		}},
+		{"SetStorage", func(proc *exec.Process, keyPtr, keyLength, valuePtr, valueLength uint32) {
+			sim.current.storage[string(read(proc, keyPtr, keyLength))] = read(proc, valuePtr, valueLength)
+		}},
+		{"DeleteStorage", func(proc *exec.Process, keyPtr, keyLength uint32) {
+			delete(sim.current.storage, string(read(proc, keyPtr, keyLength)))
+		}},
+		{"Log", func(proc *exec.Process, ptr, length uint32) {
+			sim.current.logs = append(sim.current.logs, string(read(proc, ptr, length)))
+		}},
+		{"Revert", func(proc *exec.Process, ptr, length uint32) {
+			message := string(read(proc, ptr, length))
+			sim.current.reverted = &message
+			proc.Terminate()
+		}},
+	}
+
+	host := wagon.NewModule()
+	host.Export.Entries = make(map[string]wagon.ExportEntry)
+	for i, function := range functions {
+		fn := reflect.ValueOf(function.fn)
+		sig := &wagon.FunctionSig{Form: 0x60}
+		for j := 1; j < fn.Type().NumIn(); j++ {
+			sig.ParamTypes = append(sig.ParamTypes, valueTypeOf(fn.Type().In(j)))
+		}
+		for j := 0; j < fn.Type().NumOut(); j++ {
+			sig.ReturnTypes = append(sig.ReturnTypes, valueTypeOf(fn.Type().Out(j)))
+		}
+		host.Types.Entries = append(host.Types.Entries, *sig)
+		host.FunctionIndexSpace = append(host.FunctionIndexSpace, wagon.Function{
+			Sig:  sig,
+			Body: &wagon.FunctionBody{},
+			Host: fn,
+			Name: function.name,
+		})
+		host.Export.Entries[function.name] = wagon.ExportEntry{
+			FieldStr: function.name,
+			Kind:     wagon.ExternalFunction,
+			Index:    uint32(i),
+		}
+	}
+	return host
+}
+
+func valueTypeOf(t reflect.Type) wagon.ValueType {
+	if t.Kind() == reflect.Int64 || t.Kind() == reflect.Uint64 {
+		return wagon.ValueTypeI64
+	}
+	return wagon.ValueTypeI32
+}
+
+// read copies length bytes at ptr out of the contract memory, out of bounds accesses trap.
+func read(proc *exec.Process, ptr, length uint32) []byte {
+	if uint64(ptr)+uint64(length) > uint64(proc.MemSize()) {
+		panic(fmt.Errorf("wasm: memory access out of bounds,ptr:%v length:%v", ptr, length))
+	}
+	b := make([]byte, length)
+	proc.ReadAt(b, int64(ptr))
+	return b
+}
+
+// write copies b to ptr in the contract memory, out of bounds accesses trap.
+func write(proc *exec.Process, ptr uint32, b []byte) {
+	if uint64(ptr)+uint64(len(b)) > uint64(proc.MemSize()) {
+		panic(fmt.Errorf("wasm: memory access out of bounds,ptr:%v length:%v", ptr, len(b)))
+	}
+	proc.WriteAt(b, int64(ptr))
+}
@@ -0,0 +1,127 @@
+package wasm
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/ctwel/antchain-client-go-sdk/mychain"
+	wagon "github.com/go-interpreter/wagon/wasm"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// host functions imported by the simulator test contract, in import order
+var simulatorImports = []testFunc{
+	{name: "GetCallArgs", params: []wagon.ValueType{i32}},
+	{name: "GetStorage", params: []wagon.ValueType{i32, i32, i32}, results: []wagon.ValueType{i32}},
+	{name: "SetStorage", params: []wagon.ValueType{i32, i32, i32, i32}},
+	{name: "SetReturnValue", params: []wagon.ValueType{i32, i32}},
+	{name: "GetSender", params: []wagon.ValueType{i32}},
+	{name: "Revert", params: []wagon.ValueType{i32, i32}},
+	{name: "Log", params: []wagon.ValueType{i32, i32}},
+}
+
+// The counter is stored under the one byte key at 0, loaded at 16, the int32 call arg is copied to 32
+// and the sender to 48.
+var simulatorFuncs = []testFunc{
+	{name: "add", params: []wagon.ValueType{i32, i32}, results: []wagon.ValueType{i32}, code: addCode},
+	{name: "inc", code: []byte{
+		0x41, 32, 0x10, 0, // GetCallArgs(32)
+		0x41, 0, 0x41, 1, 0x41, 16, 0x10, 1, 0x1a, // drop GetStorage(0,1,16)
+		0x41, 16, // *16 = *16 + *32
+		0x41, 16, 0x28, 2, 0,
+		0x41, 32, 0x28, 2, 0,
+		0x6a,
+		0x36, 2, 0,
+		0x41, 0, 0x41, 1, 0x41, 16, 0x41, 4, 0x10, 2, // SetStorage(0,1,16,4)
+		0x41, 16, 0x41, 4, 0x10, 3, // SetReturnValue(16,4)
+	}},
+	{name: "whoami", code: []byte{
+		0x41, 48, 0x10, 4, // GetSender(48)
+		0x41, 48, 0x41, 32, 0x10, 6, // Log(48,32)
+		0x41, 48, 0x41, 32, 0x10, 3, // SetReturnValue(48,32)
+	}},
+	{name: "fail", code: []byte{
+		0x41, 0, 0x41, 1, 0x41, 16, 0x41, 4, 0x10, 2, // SetStorage(0,1,16,4)
+		0x41, 48, 0x41, 0, 0x10, 5, // Revert(48,0)
+		0x00, // unreachable
+	}},
+	{name: "trap", code: []byte{0x00}},
+	{name: "oob", code: []byte{0x41, 0x7f, 0x41, 4, 0x10, 3}}, // SetReturnValue(-1,4)
+	// 0 - x
+	{name: "negate", params: []wagon.ValueType{i32}, results: []wagon.ValueType{i32}, code: []byte{0x41, 0, 0x20, 0, 0x6b}},
+}
+
+func newTestSimulator(t *testing.T) *Simulator {
+	sim := NewSimulator()
+	require.NoError(t, sim.Deploy("counter", buildModule(t, simulatorImports, simulatorFuncs)))
+	return sim
+}
+
+func TestSimulator_DirectParams(t *testing.T) {
+	sim := newTestSimulator(t)
+	result, err := sim.Call("alice", "counter", "add(int32,int32)", `["int32"]`, 40, 2)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{int32(42)}, result.Values)
+
+	result, err = sim.Call("alice", "counter", "negate(int8)", `["int8"]`, 5)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{int8(-5)}, result.Values)
+
+	_, err = sim.Call("alice", "counter", "add(int32,string)", `["int32"]`, 1, "2")
+	require.Error(t, err)
+}
+
+func TestSimulator_Storage(t *testing.T) {
+	sim := newTestSimulator(t)
+	blockNumber := sim.BlockNumber()
+	for i, expected := range []int32{5, 12} {
+		result, err := sim.Call("alice", "counter", "inc(int32)", `["int32"]`, []int32{5, 7}[i])
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{expected}, result.Values)
+		require.Equal(t, blockNumber+int64(i)+1, result.BlockNumber)
+	}
+	value, ok := sim.Storage("counter", "\x00")
+	require.True(t, ok)
+	require.Equal(t, uint32(12), binary.LittleEndian.Uint32(value))
+
+	sim.SetStorage("counter", "\x00", []byte{100, 0, 0, 0})
+	result, err := sim.Call("bob", "counter", "inc(int32)", `["int32"]`, -1)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{int32(99)}, result.Values)
+}
+
+func TestSimulator_Sender(t *testing.T) {
+	sim := newTestSimulator(t)
+	result, err := sim.Call("alice", "counter", "whoami()", `["Identity"]`)
+	require.NoError(t, err)
+	identity := mychain.NewIdentity("alice")
+	require.Equal(t, []interface{}{identity}, result.Values)
+	require.Equal(t, []string{string(identity[:])}, result.Logs)
+}
+
+func TestSimulator_Failures(t *testing.T) {
+	sim := newTestSimulator(t)
+	sim.SetStorage("counter", "\x00", []byte{1, 0, 0, 0})
+
+	_, err := sim.Call("alice", "counter", "fail()", `["void"]`)
+	require.Truef(t, errors.Is(err, ErrReverted), "expect revert err:%+v", err)
+	_, err = sim.Call("alice", "counter", "trap()", `["void"]`)
+	require.Error(t, err)
+	_, err = sim.Call("alice", "counter", "oob()", `["void"]`)
+	require.Error(t, err)
+	value, _ := sim.Storage("counter", "\x00")
+	require.Equal(t, []byte{1, 0, 0, 0}, value, "failed calls must not change the storage")
+
+	_, err = sim.Call("alice", "counter", "missing()", `["void"]`)
+	require.Error(t, err)
+	_, err = sim.Call("alice", "other", "add(int32,int32)", `["int32"]`, 1, 2)
+	require.Error(t, err)
+	// inc sets a 4 byte output
+	_, err = sim.Call("alice", "counter", "inc(int32)", `["int64"]`, 1)
+	require.Error(t, err)
+
+	unknown := buildModule(t, []testFunc{{name: "Missing"}}, nil)
+	require.Error(t, sim.Deploy("unknown", unknown))
+	mismatch := buildModule(t, []testFunc{{name: "GetCallArgs", params: []wagon.ValueType{i64}}}, nil)
+	require.Error(t, sim.Deploy("mismatch", mismatch))
+}
@@ -0,0 +1,211 @@
+// Package wasm encodes and decodes the parameters of mychain WASM contracts.
+package wasm
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ctwel/antchain-client-go-sdk/model"
+	"github.com/ctwel/antchain-client-go-sdk/mychain"
+	"reflect"
+	"strings"
+)
+
+type Kind int
+
+const (
+	Int8Kind Kind = iota
+	Int16Kind
+	Int32Kind
+	Int64Kind
+	Uint8Kind
+	Uint16Kind
+	Uint32Kind
+	Uint64Kind
+	BoolKind
+	StringKind
+	IdentityKind
+	VectorKind
+	VoidKind
+)
+
+// Type is a parsed model.WasmParaType, Elem is set for vectors.
+type Type struct {
+	Kind Kind
+	Elem *Type
+}
+
+var scalarTypes = map[model.WasmParaType]Kind{
+	model.INT8:     Int8Kind,
+	model.INT16:    Int16Kind,
+	model.INT32:    Int32Kind,
+	model.INT64:    Int64Kind,
+	model.UINT8:    Uint8Kind,
+	model.UINT16:   Uint16Kind,
+	model.UINT32:   Uint32Kind,
+	model.UINT64:   Uint64Kind,
+	model.BOOL:     BoolKind,
+	model.STRING:   StringKind,
+	model.IDENTITY: IdentityKind,
+	model.VOID:     VoidKind,
+}
+
+// NewType parses one of the model.WasmParaType values, vectors are only supported one level deep.
+func NewType(t string) (Type, error) {
+	t = strings.TrimSpace(t)
+	if strings.HasSuffix(t, "[]") {
+		elem, err := NewType(t[:len(t)-2])
+		if err != nil {
+			return Type{}, err
+		}
+		if elem.Kind == VectorKind || elem.Kind == VoidKind {
+			return Type{}, fmt.Errorf("wasm: unsupported type %v", t)
+		}
+		return Type{Kind: VectorKind, Elem: &elem}, nil
+	}
+	kind, ok := scalarTypes[model.WasmParaType(t)]
+	if !ok {
+		return Type{}, fmt.Errorf("wasm: unsupported type %v", t)
+	}
+	return Type{Kind: kind}, nil
+}
+
+// String returns the model.WasmParaType of t.
+func (t Type) String() string {
+	if t.Kind == VectorKind {
+		return t.Elem.String() + "[]"
+	}
+	for name, kind := range scalarTypes {
+		if kind == t.Kind {
+			return string(name)
+		}
+	}
+	return fmt.Sprintf("Kind(%v)", int(t.Kind))
+}
+
+// size is the byte size of fixed size types, 0 for the others.
+func (t Type) size() int {
+	switch t.Kind {
+	case Int8Kind, Uint8Kind, BoolKind:
+		return 1
+	case Int16Kind, Uint16Kind:
+		return 2
+	case Int32Kind, Uint32Kind:
+		return 4
+	case Int64Kind, Uint64Kind:
+		return 8
+	case IdentityKind:
+		return mychain.IdentityLength
+	}
+	return 0
+}
+
+// minSize is the fewest bytes a value of t is encoded in, the length prefix for strings and vectors.
+func (t Type) minSize() int {
+	if size := t.size(); size > 0 {
+		return size
+	}
+	return 4
+}
+
+// goType is the type of the values decoded for t.
+func (t Type) goType() reflect.Type {
+	switch t.Kind {
+	case Int8Kind:
+		return reflect.TypeOf(int8(0))
+	case Int16Kind:
+		return reflect.TypeOf(int16(0))
+	case Int32Kind:
+		return reflect.TypeOf(int32(0))
+	case Int64Kind:
+		return reflect.TypeOf(int64(0))
+	case Uint8Kind:
+		return reflect.TypeOf(uint8(0))
+	case Uint16Kind:
+		return reflect.TypeOf(uint16(0))
+	case Uint32Kind:
+		return reflect.TypeOf(uint32(0))
+	case Uint64Kind:
+		return reflect.TypeOf(uint64(0))
+	case BoolKind:
+		return reflect.TypeOf(false)
+	case StringKind:
+		return reflect.TypeOf("")
+	case IdentityKind:
+		return reflect.TypeOf(mychain.Identity{})
+	case VectorKind:
+		return reflect.SliceOf(t.Elem.goType())
+	}
+	return reflect.TypeOf((*interface{})(nil)).Elem()
+}
+
+// Signature is a parsed method signature such as add(int32,int32).
+type Signature struct {
+	Name   string
+	Inputs []Type
+}
+
+func ParseSignature(signature string) (Signature, error) {
+	signature = strings.Replace(signature, " ", "", -1)
+	open := strings.Index(signature, "(")
+	if open <= 0 || !strings.HasSuffix(signature, ")") {
+		return Signature{}, fmt.Errorf("wasm: invalid method signature %v", signature)
+	}
+	parsed := Signature{Name: signature[:open]}
+	params := signature[open+1 : len(signature)-1]
+	if params == "" {
+		return parsed, nil
+	}
+	for _, param := range strings.Split(params, ",") {
+		t, err := NewType(param)
+		if err != nil {
+			return Signature{}, err
+		}
+		if t.Kind == VoidKind {
+			return Signature{}, fmt.Errorf("wasm: void parameter in %v", signature)
+		}
+		parsed.Inputs = append(parsed.Inputs, t)
+	}
+	return parsed, nil
+}
+
+// String returns the canonical signature.
+func (signature Signature) String() string {
+	types := make([]string, len(signature.Inputs))
+	for i, input := range signature.Inputs {
+		types[i] = input.String()
+	}
+	return fmt.Sprintf("%v(%v)", signature.Name, strings.Join(types, ","))
+}
+
+// ParseOutTypes parses the outTypes json of a call, e.g. ["int32","string"]. void and an empty list
+// both mean no output.
+func ParseOutTypes(outTypes string) ([]Type, error) {
+	names := make([]string, 0)
+	if err := json.Unmarshal([]byte(outTypes), &names); err != nil {
+		return nil, fmt.Errorf("wasm: outTypes is not a json string array,outTypes:%v err:%w", outTypes, err)
+	}
+	types := make([]Type, 0, len(names))
+	for _, name := range names {
+		t, err := NewType(name)
+		if err != nil {
+			return nil, err
+		}
+		if t.Kind != VoidKind {
+			types = append(types, t)
+		}
+	}
+	return types, nil
+}
+
+// OutTypes formats types as the outTypes json of a call, no types are formatted as ["void"].
+func OutTypes(types ...Type) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = t.String()
+	}
+	if len(names) == 0 {
+		names = append(names, model.VOID)
+	}
+	bytes, _ := json.Marshal(names)
+	return string(bytes)
+}